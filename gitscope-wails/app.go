@@ -7,14 +7,20 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/gitscope/internal/git"
+	"github.com/gitscope/internal/settings"
 	"github.com/gitscope/internal/state"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 type App struct {
 	ctx context.Context
+
+	searchMu     sync.Mutex
+	cancelSearch context.CancelFunc
+	cancelBisect context.CancelFunc
 }
 
 func NewApp() *App {
@@ -45,6 +51,11 @@ func (a *App) SelectRepo() (string, error) {
 	if dir == "" {
 		return "", fmt.Errorf("no directory selected")
 	}
+	return a.setRepo(dir)
+}
+
+// setRepo makes dir the active repository.
+func (a *App) setRepo(dir string) (string, error) {
 	state.RepoPath = dir
 	return dir, nil
 }
@@ -88,7 +99,91 @@ func (a *App) Commit(msg, option string) (string, error) {
 	if state.RepoPath == "" {
 		return "", fmt.Errorf("no repository selected")
 	}
-	return git.Commit(msg, option)
+	out, err := git.Commit(msg, option)
+	if err == nil {
+		recordCommitMessage(msg)
+	}
+	return out, err
+}
+
+func (a *App) CommitWithOptions(msg string, opts git.CommitOptions) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	out, err := git.CommitWithOptions(state.RepoPath, msg, opts)
+	if err == nil && !opts.NoEdit {
+		recordCommitMessage(msg)
+	}
+	return out, err
+}
+
+// recordCommitMessage adds msg to the current repository's message history.
+// History is a convenience, so failures to persist it are ignored.
+func recordCommitMessage(msg string) {
+	msg = strings.TrimSpace(msg)
+	repo := filepath.Clean(state.RepoPath)
+	_ = settings.Update(func(s *settings.Settings) {
+		s.AddCommitMessage(repo, msg)
+	})
+}
+
+func (a *App) GetCommitTemplate() (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.CommitTemplate(state.RepoPath)
+}
+
+func (a *App) GetNamedCommitTemplates() (map[string]string, error) {
+	s, err := settings.Load()
+	if err != nil {
+		return nil, err
+	}
+	return s.CommitTemplates, nil
+}
+
+func (a *App) SaveNamedCommitTemplate(name, body string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("template name cannot be empty")
+	}
+	return settings.Update(func(s *settings.Settings) {
+		if s.CommitTemplates == nil {
+			s.CommitTemplates = make(map[string]string)
+		}
+		s.CommitTemplates[name] = body
+	})
+}
+
+func (a *App) DeleteNamedCommitTemplate(name string) error {
+	return settings.Update(func(s *settings.Settings) {
+		delete(s.CommitTemplates, name)
+	})
+}
+
+func (a *App) GetRecentCommitMessages() ([]string, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	s, err := settings.Load()
+	if err != nil {
+		return nil, err
+	}
+	return s.CommitHistory[filepath.Clean(state.RepoPath)], nil
+}
+
+func (a *App) RecoverCommitMessage() (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.RecoverCommitMessage(state.RepoPath)
+}
+
+func (a *App) LintCommitMessage(msg string) ([]git.LintViolation, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.LintRepoCommitMessage(state.RepoPath, msg)
 }
 
 func (a *App) Push(branch string) (string, error) {
@@ -133,6 +228,20 @@ func (a *App) CreateBranch(name string) (string, error) {
 	return git.CreateBranch(state.RepoPath, name)
 }
 
+func (a *App) CreateBranchWithOptions(name string, opts git.BranchOptions) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.CreateBranchWithOptions(state.RepoPath, name, opts)
+}
+
+func (a *App) PublishBranch(remote, branch string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.PublishBranch(state.RepoPath, remote, branch)
+}
+
 func (a *App) DeleteBranch(name string) (string, error) {
 	if state.RepoPath == "" {
 		return "", fmt.Errorf("no repository selected")
@@ -245,6 +354,356 @@ func (a *App) Blame(file string) (string, error) {
 	return git.Blame(state.RepoPath, file)
 }
 
+func (a *App) BlameDetails(file string, opts git.BlameOptions) (git.BlameResult, error) {
+	if state.RepoPath == "" {
+		return git.BlameResult{}, fmt.Errorf("no repository selected")
+	}
+	return git.BlameDetails(state.RepoPath, file, opts)
+}
+
+func (a *App) ListTree(rev, dir string) ([]git.TreeEntry, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.ListTree(state.RepoPath, rev, dir)
+}
+
+func (a *App) ReadTreeBlob(rev, path string) (git.BlobContent, error) {
+	if state.RepoPath == "" {
+		return git.BlobContent{}, fmt.Errorf("no repository selected")
+	}
+	return git.ReadTreeBlob(state.RepoPath, rev, path)
+}
+
+func (a *App) LastCommitsForTree(rev, dir string) (map[string]git.CommitSummary, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.LastCommitsForTree(state.RepoPath, rev, dir)
+}
+
+// ExportTreePath asks where to save path as of rev and exports it there.
+// Directories are saved as a zip archive. It returns the chosen destination,
+// or "" when the dialog was cancelled.
+func (a *App) ExportTreePath(rev, path string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	entries, err := git.ListTree(state.RepoPath, rev, filepath.Dir(path))
+	if err != nil {
+		return "", err
+	}
+	name := filepath.Base(path)
+	if path == "" || path == "/" {
+		name = filepath.Base(state.RepoPath)
+	}
+	defaultName := name + ".zip"
+	for _, e := range entries {
+		if e.Name == name && e.Type == "blob" {
+			defaultName = name
+		}
+	}
+	dest, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export",
+		DefaultFilename: defaultName,
+	})
+	if err != nil || dest == "" {
+		return "", err
+	}
+	// The dialog already asked before replacing an existing file.
+	return dest, git.ExportTreePath(state.RepoPath, rev, path, dest, true)
+}
+
+func (a *App) PreviewRestore(rev string, paths []string, target string) (git.RestorePreview, error) {
+	if state.RepoPath == "" {
+		return git.RestorePreview{}, fmt.Errorf("no repository selected")
+	}
+	return git.PreviewRestore(state.RepoPath, rev, paths, target)
+}
+
+func (a *App) RestoreFromRevision(rev string, paths []string, opts git.RestoreOptions) (git.RestorePreview, error) {
+	if state.RepoPath == "" {
+		return git.RestorePreview{}, fmt.Errorf("no repository selected")
+	}
+	return git.RestoreFromRevision(state.RepoPath, rev, paths, opts)
+}
+
+// startSearch cancels any search still running and returns the context for
+// a new one.
+func (a *App) startSearch() (context.Context, context.CancelFunc) {
+	a.searchMu.Lock()
+	defer a.searchMu.Unlock()
+	if a.cancelSearch != nil {
+		a.cancelSearch()
+	}
+	parent := a.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	a.cancelSearch = cancel
+	return ctx, cancel
+}
+
+// CancelSearch stops the running SearchCommits or GrepFiles call, which
+// then returns a cancellation error.
+func (a *App) CancelSearch() {
+	a.searchMu.Lock()
+	defer a.searchMu.Unlock()
+	if a.cancelSearch != nil {
+		a.cancelSearch()
+		a.cancelSearch = nil
+	}
+}
+
+func (a *App) SearchCommits(q git.CommitQuery) (git.CommitSearchResult, error) {
+	if state.RepoPath == "" {
+		return git.CommitSearchResult{}, fmt.Errorf("no repository selected")
+	}
+	ctx, cancel := a.startSearch()
+	defer cancel()
+	return git.SearchCommits(ctx, state.RepoPath, q)
+}
+
+func (a *App) GrepFiles(q git.GrepQuery) (git.GrepResult, error) {
+	if state.RepoPath == "" {
+		return git.GrepResult{}, fmt.Errorf("no repository selected")
+	}
+	ctx, cancel := a.startSearch()
+	defer cancel()
+	return git.GrepFiles(ctx, state.RepoPath, q)
+}
+
+func (a *App) ListSubmodules() ([]git.SubmoduleInfo, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.ListSubmodules(state.RepoPath)
+}
+
+func (a *App) AddSubmodule(url, path, branch string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.AddSubmodule(state.RepoPath, url, path, branch)
+}
+
+func (a *App) InitSubmodules(paths []string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.InitSubmodules(state.RepoPath, paths)
+}
+
+func (a *App) UpdateSubmodules(opts git.SubmoduleUpdateOptions) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.UpdateSubmodules(state.RepoPath, opts)
+}
+
+func (a *App) SyncSubmodules(recursive bool, paths []string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.SyncSubmodules(state.RepoPath, recursive, paths)
+}
+
+func (a *App) DeinitSubmodule(path string, force bool) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.DeinitSubmodule(state.RepoPath, path, force)
+}
+
+func (a *App) RemoveSubmodule(path string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.RemoveSubmodule(state.RepoPath, path)
+}
+
+func (a *App) GetLFSStatus() (git.LFSStatus, error) {
+	if state.RepoPath == "" {
+		return git.LFSStatus{}, fmt.Errorf("no repository selected")
+	}
+	return git.GetLFSStatus(state.RepoPath)
+}
+
+func (a *App) ListLFSObjects() ([]git.LFSObject, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.ListLFSObjects(state.RepoPath)
+}
+
+func (a *App) TrackLFS(pattern string, lockable bool) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.TrackLFS(state.RepoPath, pattern, lockable)
+}
+
+func (a *App) UntrackLFS(pattern string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.UntrackLFS(state.RepoPath, pattern)
+}
+
+func (a *App) PullLFS(paths []string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.PullLFS(state.RepoPath, paths)
+}
+
+func (a *App) FetchRecentLFS() (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.FetchRecentLFS(state.RepoPath)
+}
+
+func (a *App) LockLFS(path string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.LockLFS(state.RepoPath, path)
+}
+
+func (a *App) UnlockLFS(path string, force bool) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.UnlockLFS(state.RepoPath, path, force)
+}
+
+func (a *App) ListLFSLocks() ([]git.LFSLock, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.ListLFSLocks(state.RepoPath)
+}
+
+func (a *App) GetSparseCheckout() (git.SparseStatus, error) {
+	if state.RepoPath == "" {
+		return git.SparseStatus{}, fmt.Errorf("no repository selected")
+	}
+	return git.GetSparseCheckout(state.RepoPath)
+}
+
+func (a *App) EnableSparseCheckout(dirs []string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.EnableSparseCheckout(state.RepoPath, dirs)
+}
+
+func (a *App) AddSparseDirectories(dirs []string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.AddSparseDirectories(state.RepoPath, dirs)
+}
+
+func (a *App) RemoveSparseDirectories(dirs []string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.RemoveSparseDirectories(state.RepoPath, dirs)
+}
+
+func (a *App) DisableSparseCheckout() (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.DisableSparseCheckout(state.RepoPath)
+}
+
+func (a *App) ListSparseDirectories(parent string) ([]git.SparseDirectory, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.ListSparseDirectories(state.RepoPath, parent)
+}
+
+func (a *App) GetBisectState() (git.BisectState, error) {
+	if state.RepoPath == "" {
+		return git.BisectState{}, fmt.Errorf("no repository selected")
+	}
+	return git.GetBisectState(state.RepoPath)
+}
+
+func (a *App) StartBisect(bad string, good []string, paths []string) (git.BisectResult, error) {
+	if state.RepoPath == "" {
+		return git.BisectResult{}, fmt.Errorf("no repository selected")
+	}
+	return git.StartBisect(state.RepoPath, bad, good, paths)
+}
+
+func (a *App) MarkBisect(verdict, rev string) (git.BisectResult, error) {
+	if state.RepoPath == "" {
+		return git.BisectResult{}, fmt.Errorf("no repository selected")
+	}
+	return git.MarkBisect(state.RepoPath, verdict, rev)
+}
+
+func (a *App) BisectLog() (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.BisectLog(state.RepoPath)
+}
+
+func (a *App) ResetBisect() (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.ResetBisect(state.RepoPath)
+}
+
+// BisectRun runs command at each bisect step until the first bad commit is
+// found, emitting every output line as a "bisect:output" event.
+func (a *App) BisectRun(command string) (git.BisectResult, error) {
+	if state.RepoPath == "" {
+		return git.BisectResult{}, fmt.Errorf("no repository selected")
+	}
+	parent := a.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	a.searchMu.Lock()
+	if a.cancelBisect != nil {
+		a.searchMu.Unlock()
+		return git.BisectResult{}, fmt.Errorf("bisect run already in progress")
+	}
+	a.cancelBisect = cancel
+	a.searchMu.Unlock()
+	defer func() {
+		a.searchMu.Lock()
+		a.cancelBisect = nil
+		a.searchMu.Unlock()
+	}()
+	return git.BisectRun(ctx, state.RepoPath, command, func(line string) {
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "bisect:output", line)
+		}
+	})
+}
+
+// CancelBisectRun stops a running BisectRun. The bisect session itself stays
+// active at the commit being tested.
+func (a *App) CancelBisectRun() {
+	a.searchMu.Lock()
+	defer a.searchMu.Unlock()
+	if a.cancelBisect != nil {
+		a.cancelBisect()
+	}
+}
+
 func (a *App) Worktree(action, args string) (string, error) {
 	if state.RepoPath == "" {
 		return "", fmt.Errorf("no repository selected")
@@ -252,6 +711,70 @@ func (a *App) Worktree(action, args string) (string, error) {
 	return git.Worktree(state.RepoPath, action, args)
 }
 
+func (a *App) ListWorktrees() ([]git.WorktreeInfo, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.ListWorktrees(state.RepoPath)
+}
+
+func (a *App) AddWorktree(opts git.WorktreeAddOptions) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.AddWorktree(state.RepoPath, opts)
+}
+
+func (a *App) RemoveWorktree(path string, force bool) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.RemoveWorktree(state.RepoPath, path, force)
+}
+
+func (a *App) LockWorktree(path, reason string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.LockWorktree(state.RepoPath, path, reason)
+}
+
+func (a *App) UnlockWorktree(path string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.UnlockWorktree(state.RepoPath, path)
+}
+
+func (a *App) MoveWorktree(path, newPath string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.MoveWorktree(state.RepoPath, path, newPath)
+}
+
+func (a *App) RepairWorktrees(paths []string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.RepairWorktrees(state.RepoPath, paths)
+}
+
+// OpenWorktree makes the worktree at path the active repository.
+func (a *App) OpenWorktree(path string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	wt, err := git.FindWorktree(state.RepoPath, path)
+	if err != nil {
+		return "", err
+	}
+	if wt.Bare || wt.Prunable {
+		return "", fmt.Errorf("worktree %s has no working tree to open", wt.Path)
+	}
+	return a.setRepo(wt.Path)
+}
+
 func (a *App) Rebase(option, target string) (string, error) {
 	if state.RepoPath == "" {
 		return "", fmt.Errorf("no repository selected")
@@ -424,3 +947,241 @@ func (a *App) GetPreviousCommit() (string, error) {
 	}
 	return git.GetPreviousCommit()
 }
+
+func (a *App) GetBranchDetails() ([]git.BranchInfo, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.ListBranches(state.RepoPath)
+}
+
+func (a *App) SetUpstream(branch, upstream string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.SetUpstream(state.RepoPath, branch, upstream)
+}
+
+func (a *App) UnsetUpstream(branch string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.UnsetUpstream(state.RepoPath, branch)
+}
+
+func (a *App) CheckoutRemoteBranch(remoteBranch, localName string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.CheckoutRemoteBranch(state.RepoPath, remoteBranch, localName)
+}
+
+func (a *App) DeleteRemoteBranch(remote, branch string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.DeleteRemoteBranch(state.RepoPath, remote, branch)
+}
+
+func (a *App) AnalyzeStaleBranches(base string, staleDays int) ([]git.StaleBranch, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.AnalyzeStaleBranches(state.RepoPath, base, staleDays)
+}
+
+func (a *App) DeleteBranches(names []string) (git.BranchCleanupResult, error) {
+	if state.RepoPath == "" {
+		return git.BranchCleanupResult{}, fmt.Errorf("no repository selected")
+	}
+	return git.DeleteBranches(state.RepoPath, names)
+}
+
+func (a *App) RestoreDeletedBranch(name string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.RestoreDeletedBranch(state.RepoPath, name)
+}
+
+func (a *App) PruneRemoteBranches(remote string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.PruneRemoteBranches(state.RepoPath, remote)
+}
+
+func (a *App) ListStashes() ([]git.StashEntry, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.ListStashes(state.RepoPath)
+}
+
+func (a *App) StashSave(opts git.StashOptions) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.StashSave(state.RepoPath, opts)
+}
+
+func (a *App) StashShow(index int) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.StashShow(state.RepoPath, index)
+}
+
+func (a *App) StashApply(index int) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.StashApply(state.RepoPath, index)
+}
+
+func (a *App) StashPop(index int) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.StashPop(state.RepoPath, index)
+}
+
+func (a *App) StashDrop(index int) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.StashDrop(state.RepoPath, index)
+}
+
+func (a *App) StashBranch(branch string, index int) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.StashBranch(state.RepoPath, branch, index)
+}
+
+func (a *App) GetTagDetails() ([]git.TagInfo, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.ListTags(state.RepoPath)
+}
+
+func (a *App) CreateTag(name string, opts git.TagOptions) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.CreateTag(state.RepoPath, name, opts)
+}
+
+func (a *App) PushTag(remote, name string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.PushTag(state.RepoPath, remote, name)
+}
+
+func (a *App) PushAllTags(remote string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.PushAllTags(state.RepoPath, remote)
+}
+
+func (a *App) DeleteRemoteTag(remote, name string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.DeleteRemoteTag(state.RepoPath, remote, name)
+}
+
+func (a *App) CherryPickCommits(commits []string, opts git.PickOptions) (git.SequencerResult, error) {
+	if state.RepoPath == "" {
+		return git.SequencerResult{}, fmt.Errorf("no repository selected")
+	}
+	return git.CherryPickCommits(state.RepoPath, commits, opts)
+}
+
+func (a *App) RevertCommits(commits []string, opts git.PickOptions) (git.SequencerResult, error) {
+	if state.RepoPath == "" {
+		return git.SequencerResult{}, fmt.Errorf("no repository selected")
+	}
+	return git.RevertCommits(state.RepoPath, commits, opts)
+}
+
+func (a *App) SequencerControl(operation, action string) (git.SequencerResult, error) {
+	if state.RepoPath == "" {
+		return git.SequencerResult{}, fmt.Errorf("no repository selected")
+	}
+	return git.SequencerControl(state.RepoPath, operation, action)
+}
+
+func (a *App) SequencerInProgress() string {
+	if state.RepoPath == "" {
+		return ""
+	}
+	return git.SequencerInProgress(state.RepoPath)
+}
+
+func (a *App) GenerateChangelog(opts git.ChangelogOptions) (git.Changelog, error) {
+	if state.RepoPath == "" {
+		return git.Changelog{}, fmt.Errorf("no repository selected")
+	}
+	return git.GenerateChangelog(state.RepoPath, opts)
+}
+
+func (a *App) PlanVersionBump(opts git.BumpOptions) (git.BumpPlan, error) {
+	if state.RepoPath == "" {
+		return git.BumpPlan{}, fmt.Errorf("no repository selected")
+	}
+	return git.PlanVersionBump(state.RepoPath, opts)
+}
+
+func (a *App) ReleaseVersion(opts git.BumpOptions) (git.BumpPlan, error) {
+	if state.RepoPath == "" {
+		return git.BumpPlan{}, fmt.Errorf("no repository selected")
+	}
+	return git.ReleaseVersion(state.RepoPath, opts)
+}
+
+func (a *App) DiffRefs(from, to string, paths []string, opts git.DiffOptions) ([]git.DiffFile, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.DiffRefs(state.RepoPath, from, to, paths, opts)
+}
+
+func (a *App) HighlightFile(path, rev string) (git.FileView, error) {
+	if state.RepoPath == "" {
+		return git.FileView{}, fmt.Errorf("no repository selected")
+	}
+	return git.HighlightFile(state.RepoPath, path, rev)
+}
+
+func (a *App) BinaryDiff(from, to, path, oldPath string) (git.BinaryDiffResult, error) {
+	if state.RepoPath == "" {
+		return git.BinaryDiffResult{}, fmt.Errorf("no repository selected")
+	}
+	return git.BinaryDiff(state.RepoPath, from, to, path, oldPath)
+}
+
+func (a *App) CompareRefs(refA, refB string) (git.RefComparison, error) {
+	if state.RepoPath == "" {
+		return git.RefComparison{}, fmt.Errorf("no repository selected")
+	}
+	return git.CompareRefs(state.RepoPath, refA, refB)
+}
+
+func (a *App) FileHistory(path string, limit int) ([]git.FileRevision, error) {
+	if state.RepoPath == "" {
+		return nil, fmt.Errorf("no repository selected")
+	}
+	return git.FileHistory(state.RepoPath, path, limit)
+}
+
+func (a *App) FileAtRevision(path, rev string) (string, error) {
+	if state.RepoPath == "" {
+		return "", fmt.Errorf("no repository selected")
+	}
+	return git.FileAtRevision(state.RepoPath, path, rev)
+}
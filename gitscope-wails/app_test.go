@@ -1,8 +1,10 @@
 package main
 
 import (
+	"os/exec"
 	"testing"
 
+	"github.com/gitscope/internal/git"
 	"github.com/gitscope/internal/state"
 )
 
@@ -154,3 +156,61 @@ func TestRunCommandsNoRepo(t *testing.T) {
 		t.Error("expected error for empty repo path")
 	}
 }
+
+func TestGetBranchDetailsNoRepo(t *testing.T) {
+	state.RepoPath = ""
+	app := NewApp()
+	_, err := app.GetBranchDetails()
+	if err == nil {
+		t.Error("expected error for empty repo path")
+	}
+}
+
+func TestListStashesNoRepo(t *testing.T) {
+	state.RepoPath = ""
+	app := NewApp()
+	_, err := app.ListStashes()
+	if err == nil {
+		t.Error("expected error for empty repo path")
+	}
+}
+
+func TestGetRecentCommitMessagesNoRepo(t *testing.T) {
+	state.RepoPath = ""
+	app := NewApp()
+	_, err := app.GetRecentCommitMessages()
+	if err == nil {
+		t.Error("expected error for empty repo path")
+	}
+}
+
+func TestSearchCommitsNoRepo(t *testing.T) {
+	state.RepoPath = ""
+	app := NewApp()
+	_, err := app.SearchCommits(git.CommitQuery{Message: "fix"})
+	if err == nil {
+		t.Error("expected error for empty repo path")
+	}
+	app.CancelSearch()
+}
+
+func TestOpenWorktreeRejectsUnknownPath(t *testing.T) {
+	state.RepoPath = ""
+	app := NewApp()
+	if _, err := app.OpenWorktree(t.TempDir()); err == nil {
+		t.Error("expected error for empty repo path")
+	}
+
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	state.RepoPath = repo
+	defer func() { state.RepoPath = "" }()
+	if _, err := app.OpenWorktree(t.TempDir()); err == nil {
+		t.Error("expected a directory that is not a worktree to be rejected")
+	}
+	if state.RepoPath != repo {
+		t.Errorf("active repository changed to %s", state.RepoPath)
+	}
+}
@@ -10,6 +10,7 @@ import {
     CherryPick, UndoLastCommit, MagicSync, GetConflicts,
     ResolveConflict, GetBranches, GetCurrentBranch,
     ReadGitIgnore, WriteGitIgnore, RunCommands, IsGitAvailable,
+    ListWorktrees, OpenWorktree,
 } from '../wailsjs/go/main/App';
 
 let currentPage = 'dashboard';
@@ -57,7 +58,7 @@ const CATEGORIES = {
         { icon: 'diff',     label: 'Diff',     action: showDiffDialog },
         { icon: 'stash',    label: 'Stash',    action: showStashDialog },
         { icon: 'clean',    label: 'Clean',    action: showCleanDialog },
-        { icon: 'lsfiles',  label: 'Ls-Files', action: () => runGitCmd(LsFiles, 'Tracked') },
+        { icon: 'lsfiles',  label: 'Ls-Files', action: showLsFilesDialog },
         { icon: 'gitignore',label: '.gitignore', action: showGitIgnoreDialog },
     ],
     Advanced: [
@@ -431,7 +432,7 @@ function runStatus() {
             <div class="modal-header-icon">${icon('status', 14)}</div>
             <h3>Git Status</h3>
         </div>
-        ${field('statusMode', 'Format', optSelect('statusMode', ['Standard', 'Short (-s)', 'Branch (-b)']))}
+        ${field('statusMode', 'Format', optSelect('statusMode', ['Standard', 'Short (-s)', 'Branch (-b)', 'Submodules']))}
         ${modalActions('Run', `window._statusRun()`)}
     `);
     window._statusRun = async () => {
@@ -731,6 +732,22 @@ function showCleanDialog() {
     };
 }
 
+function showLsFilesDialog() {
+    showModal(`
+        <div class="modal-header">
+            <div class="modal-header-icon">${icon('lsfiles', 14)}</div>
+            <h3>Ls-Files</h3>
+        </div>
+        ${field('lsMode', 'Show', optSelect('lsMode', ['Tracked', 'Staged', 'Cached (--cached)', 'Modified (--modified)', 'Others (--others)', 'Deleted (--deleted)', 'Sparse (-t)']))}
+        ${modalActions('Run', `window._lsRun()`)}
+    `);
+    window._lsRun = async () => {
+        const opt = document.getElementById('lsMode').value;
+        document.querySelector('.modal-overlay').remove();
+        await runGitCmd(LsFiles, opt);
+    };
+}
+
 function showRebaseDialog() {
     showModal(`
         <div class="modal-header">
@@ -807,18 +824,61 @@ function showWorktreeDialog() {
             <div class="modal-header-icon">${icon('worktree', 14)}</div>
             <h3>Worktree</h3>
         </div>
-        ${field('wtAction', 'Action', optSelect('wtAction', ['List', 'Add', 'Remove', 'Prune']))}
-        ${field('wtArgs', 'Args (for Add/Remove)', textInput('wtArgs', '/path branch-name'))}
+        ${field('wtAction', 'Action', optSelect('wtAction', ['List', 'Add', 'Remove', 'Lock', 'Unlock', 'Move', 'Repair', 'Prune']))}
+        ${field('wtArgs', 'Args (quote paths with spaces)', textInput('wtArgs', "'/path with spaces' [branch-name]"))}
         ${modalActions('Run', `window._wtRun()`)}
     `);
     window._wtRun = async () => {
         const action = document.getElementById('wtAction').value;
         const args = document.getElementById('wtArgs').value.trim();
         document.querySelector('.modal-overlay').remove();
+        if (action === 'List') {
+            await showWorktreeListDialog();
+            return;
+        }
         await runGitCmd(Worktree, action, args);
     };
 }
 
+async function showWorktreeListDialog() {
+    try {
+        const worktrees = await ListWorktrees() || [];
+        const current = await GetRepoPath();
+        const overlay = document.createElement('div');
+        overlay.className = 'modal-overlay';
+        overlay.innerHTML = `<div class="modal">
+            <div class="modal-header">
+                <div class="modal-header-icon">${icon('worktree', 14)}</div>
+                <h3>Worktrees</h3>
+            </div>
+            ${worktrees.map((wt, i) => {
+                const ref = wt.Bare ? '(bare)' : wt.Detached ? '(detached HEAD)' : `[${wt.Branch}]`;
+                const flags = [wt.Main ? 'main' : '', wt.Locked ? `locked${wt.LockReason ? ': ' + wt.LockReason : ''}` : '',
+                    wt.Prunable ? `prunable${wt.PrunableReason ? ': ' + wt.PrunableReason : ''}` : ''].filter(Boolean).join(', ');
+                const canOpen = !wt.Bare && !wt.Prunable && wt.Path !== current;
+                return `
+                <div class="conflict-row">
+                    <span title="${esc(wt.Path)}">${esc(wt.Path)}  ${esc((wt.Head || '').slice(0, 7))} ${esc(ref)}${flags ? '  ' + esc(flags) : ''}</span>
+                    ${canOpen ? `<button class="btn btn-primary btn-sm" onclick="window._wtOpen(${i})">Open</button>` : ''}
+                </div>`;
+            }).join('')}
+            <div class="modal-actions">
+                <button class="btn btn-secondary" onclick="this.closest('.modal-overlay').remove()">Close</button>
+            </div>
+        </div>`;
+        overlay.addEventListener('click', e => { if (e.target === overlay) overlay.remove(); });
+        document.body.appendChild(overlay);
+        window._wtOpen = async (i) => {
+            overlay.remove();
+            try {
+                const path = await OpenWorktree(worktrees[i].Path);
+                consoleLog(`Repository: ${path}`, 'success');
+                updateRepoInfo();
+            } catch (e) { consoleLog(`Error: ${e}`, 'error'); }
+        };
+    } catch (err) { consoleLog(`Error: ${err}`, 'error'); }
+}
+
 async function showConflictsDialog() {
     try {
         const conflicts = await GetConflicts();
@@ -932,7 +992,7 @@ const DOC_TEXT = {
     Clean: `Git Clean\n${'='.repeat(40)}\n\nRemoves untracked files from working tree.\n\nCommands:\n  git clean -n    (preview)\n  git clean -f    (remove files)\n  git clean -fd   (remove files + dirs)`,
     Show: `Git Show\n${'='.repeat(40)}\n\nShows details about a Git object.\n\nCommands:\n  git show HEAD\n  git show <hash>\n  git show --stat`,
     "Ls-files": `Git Ls-files\n${'='.repeat(40)}\n\nShows files in the index and working tree.\n\nCommands:\n  git ls-files\n  git ls-files --cached\n  git ls-files --others`,
-    Worktree: `Git Worktree\n${'='.repeat(40)}\n\nManage multiple working trees.\n\nCommands:\n  git worktree list\n  git worktree add <path> <branch>\n  git worktree remove <name>`,
+    Worktree: `Git Worktree\n${'='.repeat(40)}\n\nManage multiple working trees.\n\nCommands:\n  git worktree list\n  git worktree add <path> <branch>\n  git worktree remove <name>\n  git worktree lock|unlock <path>\n  git worktree move <path> <new-path>\n  git worktree repair [<path>...]`,
     Shortlog: `Git Shortlog\n${'='.repeat(40)}\n\nSummarizes git log grouped by author.\n\nCommands:\n  git shortlog\n  git shortlog -s\n  git shortlog -n`,
     Blame: `Git Blame\n${'='.repeat(40)}\n\nShows what revision/author last modified each line.\n\nCommands:\n  git blame <file>\n  git blame -L 10,20 <file>`,
 };
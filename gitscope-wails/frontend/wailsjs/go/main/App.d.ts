@@ -1,88 +1,281 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {git} from '../models';
+
+export function AddSparseDirectories(arg1:Array<string>):Promise<string>;
+
+export function AddSubmodule(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function AddWorktree(arg1:git.WorktreeAddOptions):Promise<string>;
+
+export function AnalyzeStaleBranches(arg1:string,arg2:number):Promise<Array<git.StaleBranch>>;
+
+export function BinaryDiff(arg1:string,arg2:string,arg3:string,arg4:string):Promise<git.BinaryDiffResult>;
+
+export function BisectLog():Promise<string>;
+
+export function BisectRun(arg1:string):Promise<git.BisectResult>;
 
 export function Blame(arg1:string):Promise<string>;
 
+export function BlameDetails(arg1:string,arg2:git.BlameOptions):Promise<git.BlameResult>;
+
 export function BranchRename(arg1:string,arg2:string):Promise<string>;
 
+export function CancelBisectRun():Promise<void>;
+
+export function CancelSearch():Promise<void>;
+
+export function CheckoutRemoteBranch(arg1:string,arg2:string):Promise<string>;
+
 export function CherryPick(arg1:string):Promise<string>;
 
+export function CherryPickCommits(arg1:Array<string>,arg2:git.PickOptions):Promise<git.SequencerResult>;
+
 export function Clean(arg1:string):Promise<string>;
 
 export function Clone(arg1:string):Promise<string>;
 
 export function Commit(arg1:string,arg2:string):Promise<string>;
 
+export function CommitWithOptions(arg1:string,arg2:git.CommitOptions):Promise<string>;
+
+export function CompareRefs(arg1:string,arg2:string):Promise<git.RefComparison>;
+
+export function ConfigGet(arg1:string):Promise<string>;
+
+export function ConfigSet(arg1:string,arg2:string):Promise<string>;
+
 export function CreateBranch(arg1:string):Promise<string>;
 
+export function CreateBranchWithOptions(arg1:string,arg2:git.BranchOptions):Promise<string>;
+
+export function CreateTag(arg1:string,arg2:git.TagOptions):Promise<string>;
+
+export function DeinitSubmodule(arg1:string,arg2:boolean):Promise<string>;
+
 export function DeleteBranch(arg1:string):Promise<string>;
 
+export function DeleteBranches(arg1:Array<string>):Promise<git.BranchCleanupResult>;
+
+export function DeleteNamedCommitTemplate(arg1:string):Promise<void>;
+
+export function DeleteRemoteBranch(arg1:string,arg2:string):Promise<string>;
+
+export function DeleteRemoteTag(arg1:string,arg2:string):Promise<string>;
+
 export function Diff(arg1:string):Promise<string>;
 
+export function DiffRefs(arg1:string,arg2:string,arg3:Array<string>,arg4:git.DiffOptions):Promise<Array<git.DiffFile>>;
+
+export function DisableSparseCheckout():Promise<string>;
+
+export function EnableSparseCheckout(arg1:Array<string>):Promise<string>;
+
+export function ExportTreePath(arg1:string,arg2:string):Promise<string>;
+
 export function Fetch(arg1:string):Promise<string>;
 
+export function FetchRecentLFS():Promise<string>;
+
+export function FileAtRevision(arg1:string,arg2:string):Promise<string>;
+
+export function FileHistory(arg1:string,arg2:number):Promise<Array<git.FileRevision>>;
+
+export function GenerateChangelog(arg1:git.ChangelogOptions):Promise<git.Changelog>;
+
+export function GetBisectState():Promise<git.BisectState>;
+
+export function GetBranchDetails():Promise<Array<git.BranchInfo>>;
+
 export function GetBranches():Promise<Array<string>>;
 
+export function GetCommitTemplate():Promise<string>;
+
 export function GetConflicts():Promise<Array<string>>;
 
 export function GetCurrentBranch():Promise<string>;
 
+export function GetLFSStatus():Promise<git.LFSStatus>;
+
+export function GetNamedCommitTemplates():Promise<Record<string, string>>;
+
+export function GetPreviousCommit():Promise<string>;
+
+export function GetRecentCommitMessages():Promise<Array<string>>;
+
+export function GetRemotes():Promise<Array<git.RemoteInfo>>;
+
 export function GetRepoPath():Promise<string>;
 
+export function GetSparseCheckout():Promise<git.SparseStatus>;
+
+export function GetTagDetails():Promise<Array<git.TagInfo>>;
+
+export function GetTags():Promise<Array<string>>;
+
+export function GrepFiles(arg1:git.GrepQuery):Promise<git.GrepResult>;
+
+export function HighlightFile(arg1:string,arg2:string):Promise<git.FileView>;
+
 export function Init():Promise<string>;
 
+export function InitSubmodules(arg1:Array<string>):Promise<string>;
+
 export function IsGitAvailable():Promise<boolean>;
 
 export function IsRepoInitialized():Promise<boolean>;
 
+export function LastCommitsForTree(arg1:string,arg2:string):Promise<Record<string, git.CommitSummary>>;
+
+export function LintCommitMessage(arg1:string):Promise<Array<git.LintViolation>>;
+
+export function ListLFSLocks():Promise<Array<git.LFSLock>>;
+
+export function ListLFSObjects():Promise<Array<git.LFSObject>>;
+
+export function ListSparseDirectories(arg1:string):Promise<Array<git.SparseDirectory>>;
+
+export function ListStashes():Promise<Array<git.StashEntry>>;
+
+export function ListSubmodules():Promise<Array<git.SubmoduleInfo>>;
+
+export function ListTree(arg1:string,arg2:string):Promise<Array<git.TreeEntry>>;
+
+export function ListWorktrees():Promise<Array<git.WorktreeInfo>>;
+
+export function LockLFS(arg1:string):Promise<string>;
+
+export function LockWorktree(arg1:string,arg2:string):Promise<string>;
+
 export function Log(arg1:string):Promise<string>;
 
 export function LsFiles(arg1:string):Promise<string>;
 
 export function MagicSync():Promise<string>;
 
+export function MarkBisect(arg1:string,arg2:string):Promise<git.BisectResult>;
+
 export function Merge(arg1:string):Promise<string>;
 
+export function MoveWorktree(arg1:string,arg2:string):Promise<string>;
+
 export function OpenFolder():Promise<string>;
 
+export function OpenWorktree(arg1:string):Promise<string>;
+
+export function PlanVersionBump(arg1:git.BumpOptions):Promise<git.BumpPlan>;
+
+export function PreviewRestore(arg1:string,arg2:Array<string>,arg3:string):Promise<git.RestorePreview>;
+
+export function PruneRemoteBranches(arg1:string):Promise<string>;
+
+export function PublishBranch(arg1:string,arg2:string):Promise<string>;
+
 export function Pull(arg1:string):Promise<string>;
 
+export function PullLFS(arg1:Array<string>):Promise<string>;
+
 export function Push(arg1:string):Promise<string>;
 
+export function PushAllTags(arg1:string):Promise<string>;
+
+export function PushTag(arg1:string,arg2:string):Promise<string>;
+
 export function ReadGitIgnore():Promise<string>;
 
+export function ReadTreeBlob(arg1:string,arg2:string):Promise<git.BlobContent>;
+
 export function Rebase(arg1:string,arg2:string):Promise<string>;
 
+export function RecoverCommitMessage():Promise<string>;
+
 export function Reflog(arg1:string):Promise<string>;
 
+export function ReleaseVersion(arg1:git.BumpOptions):Promise<git.BumpPlan>;
+
 export function Remote(arg1:string,arg2:string):Promise<string>;
 
+export function RemoveSparseDirectories(arg1:Array<string>):Promise<string>;
+
+export function RemoveSubmodule(arg1:string):Promise<string>;
+
+export function RemoveWorktree(arg1:string,arg2:boolean):Promise<string>;
+
+export function RepairWorktrees(arg1:Array<string>):Promise<string>;
+
 export function Reset(arg1:string,arg2:string):Promise<string>;
 
+export function ResetBisect():Promise<string>;
+
 export function ResolveConflict(arg1:string,arg2:string):Promise<string>;
 
+export function RestoreDeletedBranch(arg1:string):Promise<string>;
+
+export function RestoreFromRevision(arg1:string,arg2:Array<string>,arg3:git.RestoreOptions):Promise<git.RestorePreview>;
+
 export function Revert(arg1:string):Promise<string>;
 
+export function RevertCommits(arg1:Array<string>,arg2:git.PickOptions):Promise<git.SequencerResult>;
+
 export function RunCommands(arg1:string):Promise<string>;
 
+export function SaveNamedCommitTemplate(arg1:string,arg2:string):Promise<void>;
+
+export function SearchCommits(arg1:git.CommitQuery):Promise<git.CommitSearchResult>;
+
 export function SelectRepo():Promise<string>;
 
+export function SequencerControl(arg1:string,arg2:string):Promise<git.SequencerResult>;
+
+export function SequencerInProgress():Promise<string>;
+
+export function SetUpstream(arg1:string,arg2:string):Promise<string>;
+
 export function Shortlog(arg1:string):Promise<string>;
 
 export function Show(arg1:string,arg2:string):Promise<string>;
 
 export function Stage(arg1:string):Promise<string>;
 
+export function StartBisect(arg1:string,arg2:Array<string>,arg3:Array<string>):Promise<git.BisectResult>;
+
 export function Stash(arg1:string):Promise<string>;
 
+export function StashApply(arg1:number):Promise<string>;
+
+export function StashBranch(arg1:string,arg2:number):Promise<string>;
+
+export function StashDrop(arg1:number):Promise<string>;
+
+export function StashPop(arg1:number):Promise<string>;
+
+export function StashSave(arg1:git.StashOptions):Promise<string>;
+
+export function StashShow(arg1:number):Promise<string>;
+
 export function Status(arg1:string):Promise<string>;
 
 export function SwitchBranch(arg1:string):Promise<string>;
 
+export function SyncSubmodules(arg1:boolean,arg2:Array<string>):Promise<string>;
+
 export function Tag(arg1:string,arg2:string):Promise<string>;
 
+export function TrackLFS(arg1:string,arg2:boolean):Promise<string>;
+
 export function UndoLastCommit():Promise<string>;
 
+export function UnlockLFS(arg1:string,arg2:boolean):Promise<string>;
+
+export function UnlockWorktree(arg1:string):Promise<string>;
+
+export function UnsetUpstream(arg1:string):Promise<string>;
+
+export function UntrackLFS(arg1:string):Promise<string>;
+
+export function UpdateSubmodules(arg1:git.SubmoduleUpdateOptions):Promise<string>;
+
 export function Worktree(arg1:string,arg2:string):Promise<string>;
 
 export function WriteGitIgnore(arg1:string):Promise<void>;
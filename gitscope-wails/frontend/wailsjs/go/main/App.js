@@ -2,18 +2,66 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddSparseDirectories(arg1) {
+  return window['go']['main']['App']['AddSparseDirectories'](arg1);
+}
+
+export function AddSubmodule(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AddSubmodule'](arg1, arg2, arg3);
+}
+
+export function AddWorktree(arg1) {
+  return window['go']['main']['App']['AddWorktree'](arg1);
+}
+
+export function AnalyzeStaleBranches(arg1, arg2) {
+  return window['go']['main']['App']['AnalyzeStaleBranches'](arg1, arg2);
+}
+
+export function BinaryDiff(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['BinaryDiff'](arg1, arg2, arg3, arg4);
+}
+
+export function BisectLog() {
+  return window['go']['main']['App']['BisectLog']();
+}
+
+export function BisectRun(arg1) {
+  return window['go']['main']['App']['BisectRun'](arg1);
+}
+
 export function Blame(arg1) {
   return window['go']['main']['App']['Blame'](arg1);
 }
 
+export function BlameDetails(arg1, arg2) {
+  return window['go']['main']['App']['BlameDetails'](arg1, arg2);
+}
+
 export function BranchRename(arg1, arg2) {
   return window['go']['main']['App']['BranchRename'](arg1, arg2);
 }
 
+export function CancelBisectRun() {
+  return window['go']['main']['App']['CancelBisectRun']();
+}
+
+export function CancelSearch() {
+  return window['go']['main']['App']['CancelSearch']();
+}
+
+export function CheckoutRemoteBranch(arg1, arg2) {
+  return window['go']['main']['App']['CheckoutRemoteBranch'](arg1, arg2);
+}
+
 export function CherryPick(arg1) {
   return window['go']['main']['App']['CherryPick'](arg1);
 }
 
+export function CherryPickCommits(arg1, arg2) {
+  return window['go']['main']['App']['CherryPickCommits'](arg1, arg2);
+}
+
 export function Clean(arg1) {
   return window['go']['main']['App']['Clean'](arg1);
 }
@@ -26,26 +74,114 @@ export function Commit(arg1, arg2) {
   return window['go']['main']['App']['Commit'](arg1, arg2);
 }
 
+export function CommitWithOptions(arg1, arg2) {
+  return window['go']['main']['App']['CommitWithOptions'](arg1, arg2);
+}
+
+export function CompareRefs(arg1, arg2) {
+  return window['go']['main']['App']['CompareRefs'](arg1, arg2);
+}
+
+export function ConfigGet(arg1) {
+  return window['go']['main']['App']['ConfigGet'](arg1);
+}
+
+export function ConfigSet(arg1, arg2) {
+  return window['go']['main']['App']['ConfigSet'](arg1, arg2);
+}
+
 export function CreateBranch(arg1) {
   return window['go']['main']['App']['CreateBranch'](arg1);
 }
 
+export function CreateBranchWithOptions(arg1, arg2) {
+  return window['go']['main']['App']['CreateBranchWithOptions'](arg1, arg2);
+}
+
+export function CreateTag(arg1, arg2) {
+  return window['go']['main']['App']['CreateTag'](arg1, arg2);
+}
+
+export function DeinitSubmodule(arg1, arg2) {
+  return window['go']['main']['App']['DeinitSubmodule'](arg1, arg2);
+}
+
 export function DeleteBranch(arg1) {
   return window['go']['main']['App']['DeleteBranch'](arg1);
 }
 
+export function DeleteBranches(arg1) {
+  return window['go']['main']['App']['DeleteBranches'](arg1);
+}
+
+export function DeleteNamedCommitTemplate(arg1) {
+  return window['go']['main']['App']['DeleteNamedCommitTemplate'](arg1);
+}
+
+export function DeleteRemoteBranch(arg1, arg2) {
+  return window['go']['main']['App']['DeleteRemoteBranch'](arg1, arg2);
+}
+
+export function DeleteRemoteTag(arg1, arg2) {
+  return window['go']['main']['App']['DeleteRemoteTag'](arg1, arg2);
+}
+
 export function Diff(arg1) {
   return window['go']['main']['App']['Diff'](arg1);
 }
 
+export function DiffRefs(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['DiffRefs'](arg1, arg2, arg3, arg4);
+}
+
+export function DisableSparseCheckout() {
+  return window['go']['main']['App']['DisableSparseCheckout']();
+}
+
+export function EnableSparseCheckout(arg1) {
+  return window['go']['main']['App']['EnableSparseCheckout'](arg1);
+}
+
+export function ExportTreePath(arg1, arg2) {
+  return window['go']['main']['App']['ExportTreePath'](arg1, arg2);
+}
+
 export function Fetch(arg1) {
   return window['go']['main']['App']['Fetch'](arg1);
 }
 
+export function FetchRecentLFS() {
+  return window['go']['main']['App']['FetchRecentLFS']();
+}
+
+export function FileAtRevision(arg1, arg2) {
+  return window['go']['main']['App']['FileAtRevision'](arg1, arg2);
+}
+
+export function FileHistory(arg1, arg2) {
+  return window['go']['main']['App']['FileHistory'](arg1, arg2);
+}
+
+export function GenerateChangelog(arg1) {
+  return window['go']['main']['App']['GenerateChangelog'](arg1);
+}
+
+export function GetBisectState() {
+  return window['go']['main']['App']['GetBisectState']();
+}
+
+export function GetBranchDetails() {
+  return window['go']['main']['App']['GetBranchDetails']();
+}
+
 export function GetBranches() {
   return window['go']['main']['App']['GetBranches']();
 }
 
+export function GetCommitTemplate() {
+  return window['go']['main']['App']['GetCommitTemplate']();
+}
+
 export function GetConflicts() {
   return window['go']['main']['App']['GetConflicts']();
 }
@@ -54,14 +190,58 @@ export function GetCurrentBranch() {
   return window['go']['main']['App']['GetCurrentBranch']();
 }
 
+export function GetLFSStatus() {
+  return window['go']['main']['App']['GetLFSStatus']();
+}
+
+export function GetNamedCommitTemplates() {
+  return window['go']['main']['App']['GetNamedCommitTemplates']();
+}
+
+export function GetPreviousCommit() {
+  return window['go']['main']['App']['GetPreviousCommit']();
+}
+
+export function GetRecentCommitMessages() {
+  return window['go']['main']['App']['GetRecentCommitMessages']();
+}
+
+export function GetRemotes() {
+  return window['go']['main']['App']['GetRemotes']();
+}
+
 export function GetRepoPath() {
   return window['go']['main']['App']['GetRepoPath']();
 }
 
+export function GetSparseCheckout() {
+  return window['go']['main']['App']['GetSparseCheckout']();
+}
+
+export function GetTagDetails() {
+  return window['go']['main']['App']['GetTagDetails']();
+}
+
+export function GetTags() {
+  return window['go']['main']['App']['GetTags']();
+}
+
+export function GrepFiles(arg1) {
+  return window['go']['main']['App']['GrepFiles'](arg1);
+}
+
+export function HighlightFile(arg1, arg2) {
+  return window['go']['main']['App']['HighlightFile'](arg1, arg2);
+}
+
 export function Init() {
   return window['go']['main']['App']['Init']();
 }
 
+export function InitSubmodules(arg1) {
+  return window['go']['main']['App']['InitSubmodules'](arg1);
+}
+
 export function IsGitAvailable() {
   return window['go']['main']['App']['IsGitAvailable']();
 }
@@ -70,6 +250,50 @@ export function IsRepoInitialized() {
   return window['go']['main']['App']['IsRepoInitialized']();
 }
 
+export function LastCommitsForTree(arg1, arg2) {
+  return window['go']['main']['App']['LastCommitsForTree'](arg1, arg2);
+}
+
+export function LintCommitMessage(arg1) {
+  return window['go']['main']['App']['LintCommitMessage'](arg1);
+}
+
+export function ListLFSLocks() {
+  return window['go']['main']['App']['ListLFSLocks']();
+}
+
+export function ListLFSObjects() {
+  return window['go']['main']['App']['ListLFSObjects']();
+}
+
+export function ListSparseDirectories(arg1) {
+  return window['go']['main']['App']['ListSparseDirectories'](arg1);
+}
+
+export function ListStashes() {
+  return window['go']['main']['App']['ListStashes']();
+}
+
+export function ListSubmodules() {
+  return window['go']['main']['App']['ListSubmodules']();
+}
+
+export function ListTree(arg1, arg2) {
+  return window['go']['main']['App']['ListTree'](arg1, arg2);
+}
+
+export function ListWorktrees() {
+  return window['go']['main']['App']['ListWorktrees']();
+}
+
+export function LockLFS(arg1) {
+  return window['go']['main']['App']['LockLFS'](arg1);
+}
+
+export function LockWorktree(arg1, arg2) {
+  return window['go']['main']['App']['LockWorktree'](arg1, arg2);
+}
+
 export function Log(arg1) {
   return window['go']['main']['App']['Log'](arg1);
 }
@@ -82,58 +306,162 @@ export function MagicSync() {
   return window['go']['main']['App']['MagicSync']();
 }
 
+export function MarkBisect(arg1, arg2) {
+  return window['go']['main']['App']['MarkBisect'](arg1, arg2);
+}
+
 export function Merge(arg1) {
   return window['go']['main']['App']['Merge'](arg1);
 }
 
+export function MoveWorktree(arg1, arg2) {
+  return window['go']['main']['App']['MoveWorktree'](arg1, arg2);
+}
+
 export function OpenFolder() {
   return window['go']['main']['App']['OpenFolder']();
 }
 
+export function OpenWorktree(arg1) {
+  return window['go']['main']['App']['OpenWorktree'](arg1);
+}
+
+export function PlanVersionBump(arg1) {
+  return window['go']['main']['App']['PlanVersionBump'](arg1);
+}
+
+export function PreviewRestore(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PreviewRestore'](arg1, arg2, arg3);
+}
+
+export function PruneRemoteBranches(arg1) {
+  return window['go']['main']['App']['PruneRemoteBranches'](arg1);
+}
+
+export function PublishBranch(arg1, arg2) {
+  return window['go']['main']['App']['PublishBranch'](arg1, arg2);
+}
+
 export function Pull(arg1) {
   return window['go']['main']['App']['Pull'](arg1);
 }
 
+export function PullLFS(arg1) {
+  return window['go']['main']['App']['PullLFS'](arg1);
+}
+
 export function Push(arg1) {
   return window['go']['main']['App']['Push'](arg1);
 }
 
+export function PushAllTags(arg1) {
+  return window['go']['main']['App']['PushAllTags'](arg1);
+}
+
+export function PushTag(arg1, arg2) {
+  return window['go']['main']['App']['PushTag'](arg1, arg2);
+}
+
 export function ReadGitIgnore() {
   return window['go']['main']['App']['ReadGitIgnore']();
 }
 
+export function ReadTreeBlob(arg1, arg2) {
+  return window['go']['main']['App']['ReadTreeBlob'](arg1, arg2);
+}
+
 export function Rebase(arg1, arg2) {
   return window['go']['main']['App']['Rebase'](arg1, arg2);
 }
 
+export function RecoverCommitMessage() {
+  return window['go']['main']['App']['RecoverCommitMessage']();
+}
+
 export function Reflog(arg1) {
   return window['go']['main']['App']['Reflog'](arg1);
 }
 
+export function ReleaseVersion(arg1) {
+  return window['go']['main']['App']['ReleaseVersion'](arg1);
+}
+
 export function Remote(arg1, arg2) {
   return window['go']['main']['App']['Remote'](arg1, arg2);
 }
 
+export function RemoveSparseDirectories(arg1) {
+  return window['go']['main']['App']['RemoveSparseDirectories'](arg1);
+}
+
+export function RemoveSubmodule(arg1) {
+  return window['go']['main']['App']['RemoveSubmodule'](arg1);
+}
+
+export function RemoveWorktree(arg1, arg2) {
+  return window['go']['main']['App']['RemoveWorktree'](arg1, arg2);
+}
+
+export function RepairWorktrees(arg1) {
+  return window['go']['main']['App']['RepairWorktrees'](arg1);
+}
+
 export function Reset(arg1, arg2) {
   return window['go']['main']['App']['Reset'](arg1, arg2);
 }
 
+export function ResetBisect() {
+  return window['go']['main']['App']['ResetBisect']();
+}
+
 export function ResolveConflict(arg1, arg2) {
   return window['go']['main']['App']['ResolveConflict'](arg1, arg2);
 }
 
+export function RestoreDeletedBranch(arg1) {
+  return window['go']['main']['App']['RestoreDeletedBranch'](arg1);
+}
+
+export function RestoreFromRevision(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RestoreFromRevision'](arg1, arg2, arg3);
+}
+
 export function Revert(arg1) {
   return window['go']['main']['App']['Revert'](arg1);
 }
 
+export function RevertCommits(arg1, arg2) {
+  return window['go']['main']['App']['RevertCommits'](arg1, arg2);
+}
+
 export function RunCommands(arg1) {
   return window['go']['main']['App']['RunCommands'](arg1);
 }
 
+export function SaveNamedCommitTemplate(arg1, arg2) {
+  return window['go']['main']['App']['SaveNamedCommitTemplate'](arg1, arg2);
+}
+
+export function SearchCommits(arg1) {
+  return window['go']['main']['App']['SearchCommits'](arg1);
+}
+
 export function SelectRepo() {
   return window['go']['main']['App']['SelectRepo']();
 }
 
+export function SequencerControl(arg1, arg2) {
+  return window['go']['main']['App']['SequencerControl'](arg1, arg2);
+}
+
+export function SequencerInProgress() {
+  return window['go']['main']['App']['SequencerInProgress']();
+}
+
+export function SetUpstream(arg1, arg2) {
+  return window['go']['main']['App']['SetUpstream'](arg1, arg2);
+}
+
 export function Shortlog(arg1) {
   return window['go']['main']['App']['Shortlog'](arg1);
 }
@@ -146,10 +474,38 @@ export function Stage(arg1) {
   return window['go']['main']['App']['Stage'](arg1);
 }
 
+export function StartBisect(arg1, arg2, arg3) {
+  return window['go']['main']['App']['StartBisect'](arg1, arg2, arg3);
+}
+
 export function Stash(arg1) {
   return window['go']['main']['App']['Stash'](arg1);
 }
 
+export function StashApply(arg1) {
+  return window['go']['main']['App']['StashApply'](arg1);
+}
+
+export function StashBranch(arg1, arg2) {
+  return window['go']['main']['App']['StashBranch'](arg1, arg2);
+}
+
+export function StashDrop(arg1) {
+  return window['go']['main']['App']['StashDrop'](arg1);
+}
+
+export function StashPop(arg1) {
+  return window['go']['main']['App']['StashPop'](arg1);
+}
+
+export function StashSave(arg1) {
+  return window['go']['main']['App']['StashSave'](arg1);
+}
+
+export function StashShow(arg1) {
+  return window['go']['main']['App']['StashShow'](arg1);
+}
+
 export function Status(arg1) {
   return window['go']['main']['App']['Status'](arg1);
 }
@@ -158,14 +514,42 @@ export function SwitchBranch(arg1) {
   return window['go']['main']['App']['SwitchBranch'](arg1);
 }
 
+export function SyncSubmodules(arg1, arg2) {
+  return window['go']['main']['App']['SyncSubmodules'](arg1, arg2);
+}
+
 export function Tag(arg1, arg2) {
   return window['go']['main']['App']['Tag'](arg1, arg2);
 }
 
+export function TrackLFS(arg1, arg2) {
+  return window['go']['main']['App']['TrackLFS'](arg1, arg2);
+}
+
 export function UndoLastCommit() {
   return window['go']['main']['App']['UndoLastCommit']();
 }
 
+export function UnlockLFS(arg1, arg2) {
+  return window['go']['main']['App']['UnlockLFS'](arg1, arg2);
+}
+
+export function UnlockWorktree(arg1) {
+  return window['go']['main']['App']['UnlockWorktree'](arg1);
+}
+
+export function UnsetUpstream(arg1) {
+  return window['go']['main']['App']['UnsetUpstream'](arg1);
+}
+
+export function UntrackLFS(arg1) {
+  return window['go']['main']['App']['UntrackLFS'](arg1);
+}
+
+export function UpdateSubmodules(arg1) {
+  return window['go']['main']['App']['UpdateSubmodules'](arg1);
+}
+
 export function Worktree(arg1, arg2) {
   return window['go']['main']['App']['Worktree'](arg1, arg2);
 }
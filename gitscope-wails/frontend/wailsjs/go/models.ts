@@ -0,0 +1,1852 @@
+export namespace git {
+	
+	export class LFSObject {
+	    Path: string;
+	    OID: string;
+	    Size: number;
+	    Downloaded: boolean;
+	    CheckedOut: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LFSObject(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.OID = source["OID"];
+	        this.Size = source["Size"];
+	        this.Downloaded = source["Downloaded"];
+	        this.CheckedOut = source["CheckedOut"];
+	    }
+	}
+	export class BinaryBlob {
+	    Path: string;
+	    Exists: boolean;
+	    Hash: string;
+	    Size: number;
+	    MimeType: string;
+	    Width: number;
+	    Height: number;
+	    Thumbnail: string;
+	    LFS?: LFSObject;
+	
+	    static createFrom(source: any = {}) {
+	        return new BinaryBlob(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Exists = source["Exists"];
+	        this.Hash = source["Hash"];
+	        this.Size = source["Size"];
+	        this.MimeType = source["MimeType"];
+	        this.Width = source["Width"];
+	        this.Height = source["Height"];
+	        this.Thumbnail = source["Thumbnail"];
+	        this.LFS = this.convertValues(source["LFS"], LFSObject);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImageDiff {
+	    SameSize: boolean;
+	    TotalPixels: number;
+	    ChangedPixels: number;
+	    ChangedPercent: number;
+	    ChangedBounds: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImageDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.SameSize = source["SameSize"];
+	        this.TotalPixels = source["TotalPixels"];
+	        this.ChangedPixels = source["ChangedPixels"];
+	        this.ChangedPercent = source["ChangedPercent"];
+	        this.ChangedBounds = source["ChangedBounds"];
+	    }
+	}
+	export class BinaryDiffResult {
+	    Binary: boolean;
+	    Old: BinaryBlob;
+	    New: BinaryBlob;
+	    Pixels?: ImageDiff;
+	
+	    static createFrom(source: any = {}) {
+	        return new BinaryDiffResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Binary = source["Binary"];
+	        this.Old = this.convertValues(source["Old"], BinaryBlob);
+	        this.New = this.convertValues(source["New"], BinaryBlob);
+	        this.Pixels = this.convertValues(source["Pixels"], ImageDiff);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommitSummary {
+	    Hash: string;
+	    ShortHash: string;
+	    Author: string;
+	    AuthorEmail: string;
+	    // Go type: time
+	    Date: any;
+	    Subject: string;
+	    Equivalent: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Hash = source["Hash"];
+	        this.ShortHash = source["ShortHash"];
+	        this.Author = source["Author"];
+	        this.AuthorEmail = source["AuthorEmail"];
+	        this.Date = this.convertValues(source["Date"], null);
+	        this.Subject = source["Subject"];
+	        this.Equivalent = source["Equivalent"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BisectState {
+	    Active: boolean;
+	    Bad: string;
+	    Good: string[];
+	    Paths: string[];
+	    Current: CommitSummary;
+	    Remaining: number;
+	    Steps: number;
+	    FirstBad?: CommitSummary;
+	
+	    static createFrom(source: any = {}) {
+	        return new BisectState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Active = source["Active"];
+	        this.Bad = source["Bad"];
+	        this.Good = source["Good"];
+	        this.Paths = source["Paths"];
+	        this.Current = this.convertValues(source["Current"], CommitSummary);
+	        this.Remaining = source["Remaining"];
+	        this.Steps = source["Steps"];
+	        this.FirstBad = this.convertValues(source["FirstBad"], CommitSummary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BisectResult {
+	    Output: string;
+	    State: BisectState;
+	
+	    static createFrom(source: any = {}) {
+	        return new BisectResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Output = source["Output"];
+	        this.State = this.convertValues(source["State"], BisectState);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class BlameCommit {
+	    Hash: string;
+	    Author: string;
+	    AuthorEmail: string;
+	    // Go type: time
+	    AuthorTime: any;
+	    Committer: string;
+	    CommitterEmail: string;
+	    // Go type: time
+	    CommitterTime: any;
+	    Summary: string;
+	    Previous: string;
+	    Boundary: boolean;
+	    Uncommitted: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BlameCommit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Hash = source["Hash"];
+	        this.Author = source["Author"];
+	        this.AuthorEmail = source["AuthorEmail"];
+	        this.AuthorTime = this.convertValues(source["AuthorTime"], null);
+	        this.Committer = source["Committer"];
+	        this.CommitterEmail = source["CommitterEmail"];
+	        this.CommitterTime = this.convertValues(source["CommitterTime"], null);
+	        this.Summary = source["Summary"];
+	        this.Previous = source["Previous"];
+	        this.Boundary = source["Boundary"];
+	        this.Uncommitted = source["Uncommitted"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BlameLine {
+	    Number: number;
+	    OrigNumber: number;
+	    OrigPath: string;
+	    Commit: string;
+	    Content: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BlameLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Number = source["Number"];
+	        this.OrigNumber = source["OrigNumber"];
+	        this.OrigPath = source["OrigPath"];
+	        this.Commit = source["Commit"];
+	        this.Content = source["Content"];
+	    }
+	}
+	export class BlameOptions {
+	    Rev: string;
+	    StartLine: number;
+	    EndLine: number;
+	    IgnoreWhitespace: boolean;
+	    DetectMoves: boolean;
+	    DetectCopies: boolean;
+	    IgnoreRevsFile: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BlameOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Rev = source["Rev"];
+	        this.StartLine = source["StartLine"];
+	        this.EndLine = source["EndLine"];
+	        this.IgnoreWhitespace = source["IgnoreWhitespace"];
+	        this.DetectMoves = source["DetectMoves"];
+	        this.DetectCopies = source["DetectCopies"];
+	        this.IgnoreRevsFile = source["IgnoreRevsFile"];
+	    }
+	}
+	export class BlameResult {
+	    Path: string;
+	    Lines: BlameLine[];
+	    Commits: Record<string, BlameCommit>;
+	
+	    static createFrom(source: any = {}) {
+	        return new BlameResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Lines = this.convertValues(source["Lines"], BlameLine);
+	        this.Commits = this.convertValues(source["Commits"], BlameCommit, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BlobContent {
+	    Path: string;
+	    Hash: string;
+	    Size: number;
+	    Binary: boolean;
+	    Content: string;
+	    Data: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BlobContent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Hash = source["Hash"];
+	        this.Size = source["Size"];
+	        this.Binary = source["Binary"];
+	        this.Content = source["Content"];
+	        this.Data = source["Data"];
+	    }
+	}
+	export class DeletedBranch {
+	    Name: string;
+	    Hash: string;
+	    BackupRef: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeletedBranch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Hash = source["Hash"];
+	        this.BackupRef = source["BackupRef"];
+	    }
+	}
+	export class BranchCleanupResult {
+	    Deleted: DeletedBranch[];
+	    Failed: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchCleanupResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Deleted = this.convertValues(source["Deleted"], DeletedBranch);
+	        this.Failed = source["Failed"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BranchInfo {
+	    Name: string;
+	    Ref: string;
+	    Remote: boolean;
+	    Current: boolean;
+	    Hash: string;
+	    Upstream: string;
+	    UpstreamGone: boolean;
+	    Ahead: number;
+	    Behind: number;
+	    // Go type: time
+	    LastCommit: any;
+	    LastAuthor: string;
+	    Subject: string;
+	    Merged: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Ref = source["Ref"];
+	        this.Remote = source["Remote"];
+	        this.Current = source["Current"];
+	        this.Hash = source["Hash"];
+	        this.Upstream = source["Upstream"];
+	        this.UpstreamGone = source["UpstreamGone"];
+	        this.Ahead = source["Ahead"];
+	        this.Behind = source["Behind"];
+	        this.LastCommit = this.convertValues(source["LastCommit"], null);
+	        this.LastAuthor = source["LastAuthor"];
+	        this.Subject = source["Subject"];
+	        this.Merged = source["Merged"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BranchOptions {
+	    StartPoint: string;
+	    Checkout: boolean;
+	    Orphan: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.StartPoint = source["StartPoint"];
+	        this.Checkout = source["Checkout"];
+	        this.Orphan = source["Orphan"];
+	    }
+	}
+	export class BumpOptions {
+	    Prefix: string;
+	    Bump: string;
+	    Channel: string;
+	    ReleaseBranch: string;
+	    Push: boolean;
+	    Remote: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BumpOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Prefix = source["Prefix"];
+	        this.Bump = source["Bump"];
+	        this.Channel = source["Channel"];
+	        this.ReleaseBranch = source["ReleaseBranch"];
+	        this.Push = source["Push"];
+	        this.Remote = source["Remote"];
+	    }
+	}
+	export class BumpPlan {
+	    LastRelease: string;
+	    Bump: string;
+	    Next: string;
+	    Commits: number;
+	    Breaking: number;
+	    Features: number;
+	    Fixes: number;
+	    Created: boolean;
+	    Pushed: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BumpPlan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.LastRelease = source["LastRelease"];
+	        this.Bump = source["Bump"];
+	        this.Next = source["Next"];
+	        this.Commits = source["Commits"];
+	        this.Breaking = source["Breaking"];
+	        this.Features = source["Features"];
+	        this.Fixes = source["Fixes"];
+	        this.Created = source["Created"];
+	        this.Pushed = source["Pushed"];
+	    }
+	}
+	export class ChangelogEntry {
+	    Hash: string;
+	    Type: string;
+	    Scope: string;
+	    Subject: string;
+	    Author: string;
+	    // Go type: time
+	    Date: any;
+	    Breaking: boolean;
+	    BreakingNote: string;
+	    PR: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangelogEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Hash = source["Hash"];
+	        this.Type = source["Type"];
+	        this.Scope = source["Scope"];
+	        this.Subject = source["Subject"];
+	        this.Author = source["Author"];
+	        this.Date = this.convertValues(source["Date"], null);
+	        this.Breaking = source["Breaking"];
+	        this.BreakingNote = source["BreakingNote"];
+	        this.PR = source["PR"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Changelog {
+	    Version: string;
+	    From: string;
+	    To: string;
+	    // Go type: time
+	    Date: any;
+	    Entries: ChangelogEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Changelog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Version = source["Version"];
+	        this.From = source["From"];
+	        this.To = source["To"];
+	        this.Date = this.convertValues(source["Date"], null);
+	        this.Entries = this.convertValues(source["Entries"], ChangelogEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ChangelogOptions {
+	    From: string;
+	    FullHistory: boolean;
+	    To: string;
+	    Format: string;
+	    Version: string;
+	    Prepend: boolean;
+	    Tag: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangelogOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.From = source["From"];
+	        this.FullHistory = source["FullHistory"];
+	        this.To = source["To"];
+	        this.Format = source["Format"];
+	        this.Version = source["Version"];
+	        this.Prepend = source["Prepend"];
+	        this.Tag = source["Tag"];
+	    }
+	}
+	export class PickaxeMatch {
+	    Rev: string;
+	    Path: string;
+	    Line: number;
+	    Column: number;
+	    Text: string;
+	    Removed: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PickaxeMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Rev = source["Rev"];
+	        this.Path = source["Path"];
+	        this.Line = source["Line"];
+	        this.Column = source["Column"];
+	        this.Text = source["Text"];
+	        this.Removed = source["Removed"];
+	    }
+	}
+	export class CommitHit {
+	    Hash: string;
+	    ShortHash: string;
+	    Author: string;
+	    AuthorEmail: string;
+	    // Go type: time
+	    Date: any;
+	    Subject: string;
+	    Equivalent: boolean;
+	    Files: string[];
+	    Matches: PickaxeMatch[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitHit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Hash = source["Hash"];
+	        this.ShortHash = source["ShortHash"];
+	        this.Author = source["Author"];
+	        this.AuthorEmail = source["AuthorEmail"];
+	        this.Date = this.convertValues(source["Date"], null);
+	        this.Subject = source["Subject"];
+	        this.Equivalent = source["Equivalent"];
+	        this.Files = source["Files"];
+	        this.Matches = this.convertValues(source["Matches"], PickaxeMatch);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommitOptions {
+	    StageAll: boolean;
+	    Amend: boolean;
+	    NoEdit: boolean;
+	    Author: string;
+	    Date: string;
+	    SignOff: boolean;
+	    Sign: boolean;
+	    SigningKey: string;
+	    NoVerify: boolean;
+	    AllowEmpty: boolean;
+	    CoAuthors: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.StageAll = source["StageAll"];
+	        this.Amend = source["Amend"];
+	        this.NoEdit = source["NoEdit"];
+	        this.Author = source["Author"];
+	        this.Date = source["Date"];
+	        this.SignOff = source["SignOff"];
+	        this.Sign = source["Sign"];
+	        this.SigningKey = source["SigningKey"];
+	        this.NoVerify = source["NoVerify"];
+	        this.AllowEmpty = source["AllowEmpty"];
+	        this.CoAuthors = source["CoAuthors"];
+	    }
+	}
+	export class CommitQuery {
+	    Message: string;
+	    Author: string;
+	    Committer: string;
+	    Pickaxe: string;
+	    PickaxeRegex: string;
+	    Rev: string;
+	    AllRefs: boolean;
+	    Paths: string[];
+	    IgnoreCase: boolean;
+	    Limit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Message = source["Message"];
+	        this.Author = source["Author"];
+	        this.Committer = source["Committer"];
+	        this.Pickaxe = source["Pickaxe"];
+	        this.PickaxeRegex = source["PickaxeRegex"];
+	        this.Rev = source["Rev"];
+	        this.AllRefs = source["AllRefs"];
+	        this.Paths = source["Paths"];
+	        this.IgnoreCase = source["IgnoreCase"];
+	        this.Limit = source["Limit"];
+	    }
+	}
+	export class CommitSearchResult {
+	    Hits: CommitHit[];
+	    Truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitSearchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Hits = this.convertValues(source["Hits"], CommitHit);
+	        this.Truncated = source["Truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class SideBySideRow {
+	    Left?: DiffLine;
+	    Right?: DiffLine;
+	
+	    static createFrom(source: any = {}) {
+	        return new SideBySideRow(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Left = this.convertValues(source["Left"], DiffLine);
+	        this.Right = this.convertValues(source["Right"], DiffLine);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WordSegment {
+	    Text: string;
+	    Changed: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WordSegment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Text = source["Text"];
+	        this.Changed = source["Changed"];
+	    }
+	}
+	export class DiffLine {
+	    Kind: string;
+	    Content: string;
+	    OldNumber: number;
+	    NewNumber: number;
+	    NoNewline: boolean;
+	    Segments: WordSegment[];
+	    Spans: highlight.Span[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Kind = source["Kind"];
+	        this.Content = source["Content"];
+	        this.OldNumber = source["OldNumber"];
+	        this.NewNumber = source["NewNumber"];
+	        this.NoNewline = source["NoNewline"];
+	        this.Segments = this.convertValues(source["Segments"], WordSegment);
+	        this.Spans = this.convertValues(source["Spans"], highlight.Span);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiffHunk {
+	    Header: string;
+	    OldStart: number;
+	    OldLines: number;
+	    NewStart: number;
+	    NewLines: number;
+	    Lines: DiffLine[];
+	    Rows: SideBySideRow[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffHunk(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Header = source["Header"];
+	        this.OldStart = source["OldStart"];
+	        this.OldLines = source["OldLines"];
+	        this.NewStart = source["NewStart"];
+	        this.NewLines = source["NewLines"];
+	        this.Lines = this.convertValues(source["Lines"], DiffLine);
+	        this.Rows = this.convertValues(source["Rows"], SideBySideRow);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiffFile {
+	    OldPath: string;
+	    NewPath: string;
+	    Status: string;
+	    Similarity: number;
+	    Binary: boolean;
+	    Hunks: DiffHunk[];
+	    OldLFS?: LFSObject;
+	    NewLFS?: LFSObject;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.OldPath = source["OldPath"];
+	        this.NewPath = source["NewPath"];
+	        this.Status = source["Status"];
+	        this.Similarity = source["Similarity"];
+	        this.Binary = source["Binary"];
+	        this.Hunks = this.convertValues(source["Hunks"], DiffHunk);
+	        this.OldLFS = this.convertValues(source["OldLFS"], LFSObject);
+	        this.NewLFS = this.convertValues(source["NewLFS"], LFSObject);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class DiffOptions {
+	    IgnoreWhitespace: string;
+	    IgnoreBlankLines: boolean;
+	    ContextLines: number;
+	    DetectRenames: boolean;
+	    RenameThreshold: number;
+	    DetectCopies: boolean;
+	    CopyThreshold: number;
+	    WordDiff: boolean;
+	    Highlight: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.IgnoreWhitespace = source["IgnoreWhitespace"];
+	        this.IgnoreBlankLines = source["IgnoreBlankLines"];
+	        this.ContextLines = source["ContextLines"];
+	        this.DetectRenames = source["DetectRenames"];
+	        this.RenameThreshold = source["RenameThreshold"];
+	        this.DetectCopies = source["DetectCopies"];
+	        this.CopyThreshold = source["CopyThreshold"];
+	        this.WordDiff = source["WordDiff"];
+	        this.Highlight = source["Highlight"];
+	    }
+	}
+	export class FileRevision {
+	    Hash: string;
+	    ShortHash: string;
+	    Author: string;
+	    AuthorEmail: string;
+	    // Go type: time
+	    Date: any;
+	    Subject: string;
+	    Equivalent: boolean;
+	    Path: string;
+	    OldPath: string;
+	    Status: string;
+	    Similarity: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileRevision(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Hash = source["Hash"];
+	        this.ShortHash = source["ShortHash"];
+	        this.Author = source["Author"];
+	        this.AuthorEmail = source["AuthorEmail"];
+	        this.Date = this.convertValues(source["Date"], null);
+	        this.Subject = source["Subject"];
+	        this.Equivalent = source["Equivalent"];
+	        this.Path = source["Path"];
+	        this.OldPath = source["OldPath"];
+	        this.Status = source["Status"];
+	        this.Similarity = source["Similarity"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FileStat {
+	    Path: string;
+	    OldPath: string;
+	    Added: number;
+	    Deleted: number;
+	    Binary: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileStat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.OldPath = source["OldPath"];
+	        this.Added = source["Added"];
+	        this.Deleted = source["Deleted"];
+	        this.Binary = source["Binary"];
+	    }
+	}
+	export class FileView {
+	    Language: string;
+	    Plain: boolean;
+	    Lines: highlight.Span[][];
+	    LFS?: LFSObject;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileView(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Language = source["Language"];
+	        this.Plain = source["Plain"];
+	        this.Lines = this.convertValues(source["Lines"], highlight.Span);
+	        this.LFS = this.convertValues(source["LFS"], LFSObject);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GrepHit {
+	    Rev: string;
+	    Path: string;
+	    Line: number;
+	    Column: number;
+	    Text: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GrepHit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Rev = source["Rev"];
+	        this.Path = source["Path"];
+	        this.Line = source["Line"];
+	        this.Column = source["Column"];
+	        this.Text = source["Text"];
+	    }
+	}
+	export class GrepQuery {
+	    Pattern: string;
+	    Regex: boolean;
+	    IgnoreCase: boolean;
+	    WholeWord: boolean;
+	    Rev: string;
+	    Paths: string[];
+	    Limit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GrepQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Pattern = source["Pattern"];
+	        this.Regex = source["Regex"];
+	        this.IgnoreCase = source["IgnoreCase"];
+	        this.WholeWord = source["WholeWord"];
+	        this.Rev = source["Rev"];
+	        this.Paths = source["Paths"];
+	        this.Limit = source["Limit"];
+	    }
+	}
+	export class GrepResult {
+	    Hits: GrepHit[];
+	    Truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GrepResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Hits = this.convertValues(source["Hits"], GrepHit);
+	        this.Truncated = source["Truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class LFSLock {
+	    ID: string;
+	    Path: string;
+	    Owner: string;
+	    // Go type: time
+	    LockedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new LFSLock(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.Path = source["Path"];
+	        this.Owner = source["Owner"];
+	        this.LockedAt = this.convertValues(source["LockedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class LFSPattern {
+	    Pattern: string;
+	    Source: string;
+	    Lockable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LFSPattern(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Pattern = source["Pattern"];
+	        this.Source = source["Source"];
+	        this.Lockable = source["Lockable"];
+	    }
+	}
+	export class LFSStatus {
+	    Installed: boolean;
+	    Version: string;
+	    Used: boolean;
+	    Patterns: LFSPattern[];
+	    Endpoint: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LFSStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Installed = source["Installed"];
+	        this.Version = source["Version"];
+	        this.Used = source["Used"];
+	        this.Patterns = this.convertValues(source["Patterns"], LFSPattern);
+	        this.Endpoint = source["Endpoint"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LintViolation {
+	    Rule: string;
+	    Severity: string;
+	    Line: number;
+	    Message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LintViolation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Rule = source["Rule"];
+	        this.Severity = source["Severity"];
+	        this.Line = source["Line"];
+	        this.Message = source["Message"];
+	    }
+	}
+	export class PickOptions {
+	    RecordOrigin: boolean;
+	    Mainline: number;
+	    NoCommit: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PickOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.RecordOrigin = source["RecordOrigin"];
+	        this.Mainline = source["Mainline"];
+	        this.NoCommit = source["NoCommit"];
+	    }
+	}
+	
+	export class RefComparison {
+	    A: string;
+	    B: string;
+	    MergeBase: string;
+	    OnlyA: CommitSummary[];
+	    OnlyB: CommitSummary[];
+	    Files: FileStat[];
+	    Added: number;
+	    Deleted: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RefComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.A = source["A"];
+	        this.B = source["B"];
+	        this.MergeBase = source["MergeBase"];
+	        this.OnlyA = this.convertValues(source["OnlyA"], CommitSummary);
+	        this.OnlyB = this.convertValues(source["OnlyB"], CommitSummary);
+	        this.Files = this.convertValues(source["Files"], FileStat);
+	        this.Added = source["Added"];
+	        this.Deleted = source["Deleted"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RemoteInfo {
+	    Name: string;
+	    FetchURL: string;
+	    PushURL: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RemoteInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.FetchURL = source["FetchURL"];
+	        this.PushURL = source["PushURL"];
+	    }
+	}
+	export class RestoreChange {
+	    Path: string;
+	    Action: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RestoreChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Action = source["Action"];
+	    }
+	}
+	export class RestoreOptions {
+	    Target: string;
+	    Force: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RestoreOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Target = source["Target"];
+	        this.Force = source["Force"];
+	    }
+	}
+	export class RestorePreview {
+	    Changes: RestoreChange[];
+	    Uncommitted: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RestorePreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Changes = this.convertValues(source["Changes"], RestoreChange);
+	        this.Uncommitted = source["Uncommitted"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SequencerResult {
+	    Output: string;
+	    InProgress: boolean;
+	    Conflicts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SequencerResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Output = source["Output"];
+	        this.InProgress = source["InProgress"];
+	        this.Conflicts = source["Conflicts"];
+	    }
+	}
+	
+	export class SparseDirectory {
+	    Path: string;
+	    Included: boolean;
+	    Partial: boolean;
+	    Files: number;
+	    Size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SparseDirectory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Included = source["Included"];
+	        this.Partial = source["Partial"];
+	        this.Files = source["Files"];
+	        this.Size = source["Size"];
+	    }
+	}
+	export class SparseStatus {
+	    Enabled: boolean;
+	    Cone: boolean;
+	    Directories: string[];
+	    Patterns: string[];
+	    TrackedFiles: number;
+	    PresentFiles: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SparseStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Enabled = source["Enabled"];
+	        this.Cone = source["Cone"];
+	        this.Directories = source["Directories"];
+	        this.Patterns = source["Patterns"];
+	        this.TrackedFiles = source["TrackedFiles"];
+	        this.PresentFiles = source["PresentFiles"];
+	    }
+	}
+	export class StaleBranch {
+	    Branch: BranchInfo;
+	    MergedIntoBase: boolean;
+	    UpstreamGone: boolean;
+	    Inactive: boolean;
+	    AgeDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StaleBranch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Branch = this.convertValues(source["Branch"], BranchInfo);
+	        this.MergedIntoBase = source["MergedIntoBase"];
+	        this.UpstreamGone = source["UpstreamGone"];
+	        this.Inactive = source["Inactive"];
+	        this.AgeDays = source["AgeDays"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StashEntry {
+	    Index: number;
+	    Ref: string;
+	    Hash: string;
+	    Branch: string;
+	    Message: string;
+	    // Go type: time
+	    Date: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new StashEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Index = source["Index"];
+	        this.Ref = source["Ref"];
+	        this.Hash = source["Hash"];
+	        this.Branch = source["Branch"];
+	        this.Message = source["Message"];
+	        this.Date = this.convertValues(source["Date"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StashOptions {
+	    Message: string;
+	    IncludeUntracked: boolean;
+	    KeepIndex: boolean;
+	    Paths: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StashOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Message = source["Message"];
+	        this.IncludeUntracked = source["IncludeUntracked"];
+	        this.KeepIndex = source["KeepIndex"];
+	        this.Paths = source["Paths"];
+	    }
+	}
+	export class SubmoduleInfo {
+	    Name: string;
+	    Path: string;
+	    URL: string;
+	    Branch: string;
+	    RecordedCommit: string;
+	    CheckedOutCommit: string;
+	    Initialized: boolean;
+	    CheckedOut: boolean;
+	    NewCommits: boolean;
+	    ModifiedContent: boolean;
+	    UntrackedContent: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubmoduleInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Path = source["Path"];
+	        this.URL = source["URL"];
+	        this.Branch = source["Branch"];
+	        this.RecordedCommit = source["RecordedCommit"];
+	        this.CheckedOutCommit = source["CheckedOutCommit"];
+	        this.Initialized = source["Initialized"];
+	        this.CheckedOut = source["CheckedOut"];
+	        this.NewCommits = source["NewCommits"];
+	        this.ModifiedContent = source["ModifiedContent"];
+	        this.UntrackedContent = source["UntrackedContent"];
+	    }
+	}
+	export class SubmoduleUpdateOptions {
+	    Init: boolean;
+	    Remote: boolean;
+	    Recursive: boolean;
+	    Paths: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SubmoduleUpdateOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Init = source["Init"];
+	        this.Remote = source["Remote"];
+	        this.Recursive = source["Recursive"];
+	        this.Paths = source["Paths"];
+	    }
+	}
+	export class TagInfo {
+	    Name: string;
+	    Target: string;
+	    Annotated: boolean;
+	    Tagger: string;
+	    // Go type: time
+	    Date: any;
+	    Subject: string;
+	    Message: string;
+	    Signed: boolean;
+	    SignatureStatus: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TagInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Target = source["Target"];
+	        this.Annotated = source["Annotated"];
+	        this.Tagger = source["Tagger"];
+	        this.Date = this.convertValues(source["Date"], null);
+	        this.Subject = source["Subject"];
+	        this.Message = source["Message"];
+	        this.Signed = source["Signed"];
+	        this.SignatureStatus = source["SignatureStatus"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TagOptions {
+	    Message: string;
+	    Target: string;
+	    Sign: boolean;
+	    SigningKey: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TagOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Message = source["Message"];
+	        this.Target = source["Target"];
+	        this.Sign = source["Sign"];
+	        this.SigningKey = source["SigningKey"];
+	    }
+	}
+	export class TreeEntry {
+	    Name: string;
+	    Path: string;
+	    Mode: string;
+	    Type: string;
+	    Hash: string;
+	    Size: number;
+	    Submodule: boolean;
+	    Symlink: boolean;
+	    Executable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TreeEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Path = source["Path"];
+	        this.Mode = source["Mode"];
+	        this.Type = source["Type"];
+	        this.Hash = source["Hash"];
+	        this.Size = source["Size"];
+	        this.Submodule = source["Submodule"];
+	        this.Symlink = source["Symlink"];
+	        this.Executable = source["Executable"];
+	    }
+	}
+	
+	export class WorktreeAddOptions {
+	    Path: string;
+	    Commit: string;
+	    NewBranch: string;
+	    Detach: boolean;
+	    Track: boolean;
+	    Lock: boolean;
+	    Force: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorktreeAddOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Commit = source["Commit"];
+	        this.NewBranch = source["NewBranch"];
+	        this.Detach = source["Detach"];
+	        this.Track = source["Track"];
+	        this.Lock = source["Lock"];
+	        this.Force = source["Force"];
+	    }
+	}
+	export class WorktreeInfo {
+	    Path: string;
+	    Head: string;
+	    Branch: string;
+	    Bare: boolean;
+	    Detached: boolean;
+	    Main: boolean;
+	    Locked: boolean;
+	    LockReason: string;
+	    Prunable: boolean;
+	    PrunableReason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorktreeInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Head = source["Head"];
+	        this.Branch = source["Branch"];
+	        this.Bare = source["Bare"];
+	        this.Detached = source["Detached"];
+	        this.Main = source["Main"];
+	        this.Locked = source["Locked"];
+	        this.LockReason = source["LockReason"];
+	        this.Prunable = source["Prunable"];
+	        this.PrunableReason = source["PrunableReason"];
+	    }
+	}
+
+}
+
+export namespace highlight {
+	
+	export class Span {
+	    Text: string;
+	    Class: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Span(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Text = source["Text"];
+	        this.Class = source["Class"];
+	    }
+	}
+
+}
+
@@ -21,10 +21,16 @@ func OpenRepo(w fyne.Window, onResult func(string)) {
 			return
 		}
 
-		state.RepoPath = uri.Path() // Assign to global variable (no :=)
-		onResult("Repository set to: " + state.RepoPath)
+		onResult(SetRepo(uri.Path()))
 	}, w).Show()
 }
+
+// SetRepo makes path the active repository and returns a status message.
+func SetRepo(path string) string {
+	state.RepoPath = path // Assign to global variable (no :=)
+	return "Repository set to: " + state.RepoPath
+}
+
 func CreateNewRepo(w fyne.Window, onSubmit func(string)) fyne.CanvasObject {
 	label := widget.NewLabel("Create New Repository")
 	multiline := widget.NewMultiLineEntry()
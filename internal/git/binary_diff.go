@@ -0,0 +1,380 @@
+package git
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ThumbnailSize is the longest edge, in pixels, of the thumbnails returned
+// by BinaryDiff.
+const ThumbnailSize = 256
+
+// maxImageBytes bounds the blobs BinaryDiff decodes as images, and
+// maxImagePixels the dimensions they may declare, since a small compressed
+// file can describe an image that takes gigabytes to decode.
+const (
+	maxImageBytes  = 32 << 20
+	maxImagePixels = 40_000_000
+)
+
+// BinaryBlob describes one side of a binary file diff.
+type BinaryBlob struct {
+	Path   string
+	Exists bool
+	Hash   string
+	Size   int64
+	// MimeType is sniffed from the content, e.g. "image/png".
+	MimeType string
+	// Width and Height are set for PNG, JPEG, GIF and SVG images.
+	Width  int
+	Height int
+	// Thumbnail is a data: URL previewing the image, empty for other files.
+	Thumbnail string
+	// LFS is set when the blob is an LFS pointer; the other fields then
+	// describe the pointer, not the object.
+	LFS *LFSObject
+}
+
+// ImageDiff compares the pixels of two raster images of the same size.
+type ImageDiff struct {
+	SameSize       bool
+	TotalPixels    int
+	ChangedPixels  int
+	ChangedPercent float64
+	// ChangedBounds is the smallest rectangle containing every changed
+	// pixel, as [minX, minY, maxX, maxY). It is empty when nothing changed.
+	ChangedBounds []int
+}
+
+// BinaryDiffResult holds both sides of a binary file diff.
+type BinaryDiffResult struct {
+	// Binary reports whether git treats the file as binary, either from
+	// .gitattributes or from its content.
+	Binary bool
+	Old    BinaryBlob
+	New    BinaryBlob
+	// Pixels is set when both sides are raster images.
+	Pixels *ImageDiff
+}
+
+// BinaryDiff compares path between revisions a and b. An empty b uses the
+// working tree copy. oldPath names the file at a when it was renamed and
+// defaults to path.
+func BinaryDiff(repoPath, a, b, path, oldPath string) (BinaryDiffResult, error) {
+	var res BinaryDiffResult
+	if err := validateGitRepo(repoPath); err != nil {
+		return res, err
+	}
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if a == "" {
+		return res, errors.New("a revision to compare is required")
+	}
+	if strings.HasPrefix(a, "-") || strings.HasPrefix(b, "-") {
+		return res, errors.New("invalid revision")
+	}
+	if strings.TrimSpace(path) == "" {
+		return res, errors.New("a path is required")
+	}
+	path, err := cleanRepoPath(path)
+	if err != nil {
+		return res, err
+	}
+	if strings.TrimSpace(oldPath) == "" {
+		oldPath = path
+	}
+	if oldPath, err = cleanRepoPath(oldPath); err != nil {
+		return res, err
+	}
+
+	binary, err := isBinaryPath(repoPath, a, b, path, oldPath)
+	if err != nil {
+		return res, err
+	}
+	res.Binary = binary
+
+	oldData, err := loadBlob(repoPath, a, oldPath, &res.Old)
+	if err != nil {
+		return res, err
+	}
+	newData, err := loadBlob(repoPath, b, path, &res.New)
+	if err != nil {
+		return res, err
+	}
+	res.Old.LFS = lfsPointerObject(repoPath, oldPath, oldData)
+	res.New.LFS = lfsPointerObject(repoPath, path, newData)
+	if res.Old.LFS != nil || res.New.LFS != nil {
+		return res, nil
+	}
+	oldImg := describeImage(oldData, &res.Old)
+	newImg := describeImage(newData, &res.New)
+	if oldImg != nil && newImg != nil {
+		d := compareImages(oldImg, newImg)
+		res.Pixels = &d
+	}
+	return res, nil
+}
+
+// isBinaryPath reports whether git treats the file as binary. The "binary"
+// and "-diff" attributes win; otherwise `git diff --numstat` prints "-"
+// counts for files it considers binary.
+func isBinaryPath(repoPath, a, b, path, oldPath string) (bool, error) {
+	attr := exec.Command("git", "-C", repoPath, "check-attr", "binary", "diff", "--", path)
+	hideWindow(attr)
+	if out, err := attr.Output(); err == nil {
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			if strings.HasSuffix(line, ": binary: set") || strings.HasSuffix(line, ": diff: unset") {
+				return true, nil
+			}
+		}
+	}
+
+	args := []string{"-C", repoPath, "diff", "--numstat", "--no-renames", a}
+	if b != "" {
+		args = append(args, b)
+	}
+	args = append(args, "--", path)
+	if oldPath != path {
+		args = append(args, oldPath)
+	}
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("diff failed: %v", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "-\t-\t") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// loadBlob fills blob with the hash, size and type of path at rev, or in
+// the working tree when rev is empty, and returns its content. Content is
+// only read when it is small enough to be decoded as an image.
+func loadBlob(repoPath, rev, path string, blob *BinaryBlob) ([]byte, error) {
+	blob.Path = path
+	if rev == "" {
+		path, err := cleanRepoPath(path)
+		if err != nil {
+			return nil, err
+		}
+		full := filepath.Join(repoPath, filepath.FromSlash(path))
+		info, err := os.Stat(full)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		blob.Exists = true
+		blob.Size = info.Size()
+		cmd := exec.Command("git", "-C", repoPath, "hash-object", "--", path)
+		hideWindow(cmd)
+		if out, err := cmd.Output(); err == nil {
+			blob.Hash = strings.TrimSpace(string(out))
+		}
+		if blob.Size > maxImageBytes {
+			return nil, nil
+		}
+		return os.ReadFile(full)
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", rev+":"+path)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		// The path does not exist at rev, e.g. for added or deleted files.
+		return nil, nil
+	}
+	blob.Exists = true
+	blob.Hash = strings.TrimSpace(string(out))
+
+	size := exec.Command("git", "-C", repoPath, "cat-file", "-s", blob.Hash)
+	hideWindow(size)
+	out, err = size.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading blob size failed: %v", err)
+	}
+	blob.Size, _ = strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if blob.Size > maxImageBytes {
+		return nil, nil
+	}
+	content := exec.Command("git", "-C", repoPath, "cat-file", "blob", blob.Hash)
+	hideWindow(content)
+	data, err := content.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading blob failed: %v", err)
+	}
+	return data, nil
+}
+
+// describeImage sets the type, dimensions and thumbnail of blob from data
+// and returns the decoded raster image, or nil for other content.
+func describeImage(data []byte, blob *BinaryBlob) image.Image {
+	if len(data) == 0 {
+		return nil
+	}
+	blob.MimeType = http.DetectContentType(data)
+	if isSVG(blob.Path, data) {
+		blob.MimeType = "image/svg+xml"
+		blob.Width, blob.Height = svgSize(data)
+		blob.Thumbnail = "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(data)
+		return nil
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	blob.Width, blob.Height = cfg.Width, cfg.Height
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > maxImagePixels {
+		return nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	bounds := img.Bounds()
+	blob.Width, blob.Height = bounds.Dx(), bounds.Dy()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, thumbnail(img, ThumbnailSize)); err == nil {
+		blob.Thumbnail = "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+	return img
+}
+
+func isSVG(path string, data []byte) bool {
+	if strings.EqualFold(filepath.Ext(path), ".svg") {
+		return true
+	}
+	// Otherwise the root element decides; the prolog may hold an XML
+	// declaration, comments and a doctype.
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			return t.Name.Local == "svg"
+		case xml.CharData:
+			if len(bytes.TrimSpace(t)) > 0 {
+				return false
+			}
+		}
+	}
+}
+
+// svgSize reads the size of an SVG document from its width and height
+// attributes, falling back to the viewBox.
+func svgSize(data []byte) (int, int) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, 0
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "svg" {
+			continue
+		}
+		var w, h int
+		var viewBox string
+		for _, attr := range start.Attr {
+			switch attr.Name.Local {
+			case "width":
+				w = svgLength(attr.Value)
+			case "height":
+				h = svgLength(attr.Value)
+			case "viewBox":
+				viewBox = attr.Value
+			}
+		}
+		if (w == 0 || h == 0) && viewBox != "" {
+			if f := strings.Fields(strings.ReplaceAll(viewBox, ",", " ")); len(f) == 4 {
+				vw, _ := strconv.ParseFloat(f[2], 64)
+				vh, _ := strconv.ParseFloat(f[3], 64)
+				w, h = int(vw), int(vh)
+			}
+		}
+		return w, h
+	}
+}
+
+// svgLength parses a length such as "24", "24px" or "24.5"; relative units
+// such as "%" or "em" are ignored.
+func svgLength(v string) int {
+	v = strings.TrimSuffix(strings.TrimSpace(v), "px")
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0
+	}
+	return int(f)
+}
+
+// thumbnail scales img down with nearest-neighbour sampling so its longest
+// edge is at most size pixels.
+func thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return img
+	}
+	tw, th := size, h*size/w
+	if h > w {
+		tw, th = w*size/h, size
+	}
+	tw, th = max(tw, 1), max(th, 1)
+	dst := image.NewNRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		for x := 0; x < tw; x++ {
+			dst.Set(x, y, img.At(b.Min.X+x*w/tw, b.Min.Y+y*h/th))
+		}
+	}
+	return dst
+}
+
+// compareImages counts the pixels that differ between two images of the
+// same size.
+func compareImages(a, b image.Image) ImageDiff {
+	ab, bb := a.Bounds(), b.Bounds()
+	d := ImageDiff{SameSize: ab.Dx() == bb.Dx() && ab.Dy() == bb.Dy()}
+	if !d.SameSize {
+		return d
+	}
+	d.TotalPixels = ab.Dx() * ab.Dy()
+	minX, minY, maxX, maxY := ab.Dx(), ab.Dy(), -1, -1
+	for y := 0; y < ab.Dy(); y++ {
+		for x := 0; x < ab.Dx(); x++ {
+			ca := color.NRGBAModel.Convert(a.At(ab.Min.X+x, ab.Min.Y+y))
+			cb := color.NRGBAModel.Convert(b.At(bb.Min.X+x, bb.Min.Y+y))
+			if ca == cb {
+				continue
+			}
+			d.ChangedPixels++
+			minX, minY = min(minX, x), min(minY, y)
+			maxX, maxY = max(maxX, x), max(maxY, y)
+		}
+	}
+	if d.ChangedPixels > 0 {
+		d.ChangedPercent = float64(d.ChangedPixels) * 100 / float64(d.TotalPixels)
+		d.ChangedBounds = []int{minX, minY, maxX + 1, maxY + 1}
+	}
+	return d
+}
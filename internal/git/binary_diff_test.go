@@ -0,0 +1,147 @@
+package git
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePNG(t *testing.T, path string, mark bool) {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 3))
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	if mark {
+		img.Set(2, 1, color.NRGBA{B: 255, A: 255})
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBinaryDiffImage(t *testing.T) {
+	dir := initTestRepo(t)
+	writePNG(t, filepath.Join(dir, "logo.png"), false)
+	gitRun(t, dir, "add", "logo.png")
+	gitRun(t, dir, "commit", "-m", "add logo")
+	writePNG(t, filepath.Join(dir, "logo.png"), true)
+
+	res, err := BinaryDiff(dir, "HEAD", "", "logo.png", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Binary || !res.Old.Exists || !res.New.Exists || res.Old.Hash == res.New.Hash {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if res.Old.MimeType != "image/png" || res.New.Width != 4 || res.New.Height != 3 {
+		t.Errorf("unexpected image metadata: %+v", res.New)
+	}
+	if !strings.HasPrefix(res.Old.Thumbnail, "data:image/png;base64,") {
+		t.Errorf("missing thumbnail: %q", res.Old.Thumbnail)
+	}
+	p := res.Pixels
+	if p == nil || !p.SameSize || p.TotalPixels != 12 || p.ChangedPixels != 1 {
+		t.Fatalf("unexpected pixel diff: %+v", p)
+	}
+	if b := p.ChangedBounds; len(b) != 4 || b[0] != 2 || b[1] != 1 || b[2] != 3 || b[3] != 2 {
+		t.Errorf("unexpected changed bounds: %v", b)
+	}
+
+	res, err = BinaryDiff(dir, "HEAD~1", "HEAD", "logo.png", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Old.Exists || !res.New.Exists || res.Pixels != nil {
+		t.Errorf("expected an added file: %+v", res)
+	}
+}
+
+func TestBinaryDiffAttributes(t *testing.T) {
+	dir := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.dat binary\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data.dat"), []byte("text\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	res, err := BinaryDiff(dir, "HEAD", "", "data.dat", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Binary || res.Old.Exists || res.New.Size != 5 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	res, err = BinaryDiff(dir, "HEAD", "", "README.md", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Binary {
+		t.Error("README.md should not be binary")
+	}
+}
+
+func TestSVGSize(t *testing.T) {
+	w, h := svgSize([]byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" width="24px" height="16"></svg>`))
+	if w != 24 || h != 16 {
+		t.Errorf("got %dx%d", w, h)
+	}
+	w, h = svgSize([]byte(`<svg viewBox="0 0 100 50"><rect/></svg>`))
+	if w != 100 || h != 50 {
+		t.Errorf("got %dx%d from viewBox", w, h)
+	}
+}
+
+func TestIsSVG(t *testing.T) {
+	if !isSVG("icon.svg", []byte("not even xml")) {
+		t.Error("expected .svg extension to be trusted")
+	}
+	if !isSVG("icon", []byte("<?xml version=\"1.0\"?>\n<!-- logo -->\n<svg width=\"1\"/>")) {
+		t.Error("expected svg root element to be detected")
+	}
+	if isSVG("page.html", []byte("<html><body><svg width=\"1\"/></body></html>")) {
+		t.Error("expected embedded svg not to make a document an SVG")
+	}
+}
+
+func TestDescribeImageRejectsHugeDimensions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	// Rewrite the IHDR chunk, which follows the 8-byte signature, to claim
+	// 100000x100000 pixels and fix up its checksum.
+	binary.BigEndian.PutUint32(data[16:], 100000)
+	binary.BigEndian.PutUint32(data[20:], 100000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	blob := BinaryBlob{Path: "huge.png"}
+	if img := describeImage(data, &blob); img != nil {
+		t.Fatal("expected oversized image not to be decoded")
+	}
+	if blob.Width != 100000 || blob.Height != 100000 || blob.Thumbnail != "" {
+		t.Errorf("unexpected blob: %+v", blob)
+	}
+}
+
+func TestBinaryDiffRejectsPathOutsideRepo(t *testing.T) {
+	dir := initTestRepo(t)
+	if _, err := BinaryDiff(dir, "HEAD", "", "../outside.png", ""); err == nil {
+		t.Error("expected path outside the repository to be rejected")
+	}
+}
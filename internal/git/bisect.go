@@ -0,0 +1,332 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BisectState describes a bisect session.
+type BisectState struct {
+	Active bool
+	Bad    string
+	Good   []string
+	// Paths restricts the search to commits touching these paths.
+	Paths []string
+	// Current is the commit checked out for testing.
+	Current CommitSummary
+	// Remaining is the number of commits still suspected, and Steps the
+	// estimated number of tests left to find the first bad one.
+	Remaining int
+	Steps     int
+	// FirstBad is set once the first bad commit has been found.
+	FirstBad *CommitSummary
+}
+
+// BisectResult is the output of a bisect command and the state it left.
+type BisectResult struct {
+	Output string
+	State  BisectState
+}
+
+func validBisectRef(ref string) error {
+	if strings.TrimSpace(ref) == "" || strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid revision %q", ref)
+	}
+	return nil
+}
+
+// runBisect runs `git bisect <args>` and returns its output with the new state.
+func runBisect(repoPath string, args ...string) (BisectResult, error) {
+	var res BisectResult
+	if err := validateGitRepo(repoPath); err != nil {
+		return res, err
+	}
+	cmd := exec.Command("git", append([]string{"-C", repoPath, "bisect"}, args...)...)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	res.Output = string(out)
+	if err != nil {
+		return res, fmt.Errorf("bisect %s failed: %v\n%s", args[0], err, string(out))
+	}
+	res.State, err = GetBisectState(repoPath)
+	return res, err
+}
+
+// StartBisect starts bisecting between a bad and one or more good
+// revisions, optionally considering only commits that touch paths.
+func StartBisect(repoPath, bad string, good []string, paths []string) (BisectResult, error) {
+	if err := validBisectRef(bad); err != nil {
+		return BisectResult{}, err
+	}
+	if len(good) == 0 {
+		return BisectResult{}, errors.New("at least one good revision is required")
+	}
+	for _, g := range good {
+		if err := validBisectRef(g); err != nil {
+			return BisectResult{}, err
+		}
+	}
+	if gitPathExists(repoPath, "BISECT_START") {
+		return BisectResult{}, errors.New("a bisect is already in progress; reset it first")
+	}
+	args := append([]string{"start", bad}, good...)
+	args = append(args, "--")
+	args = append(args, paths...)
+	return runBisect(repoPath, args...)
+}
+
+// MarkBisect marks rev, or the current commit when rev is empty, as
+// "good", "bad" or "skip" and checks out the next candidate.
+func MarkBisect(repoPath, verdict, rev string) (BisectResult, error) {
+	switch verdict {
+	case "good", "bad", "skip":
+	default:
+		return BisectResult{}, fmt.Errorf("unknown bisect verdict: %s", verdict)
+	}
+	args := []string{verdict}
+	if rev = strings.TrimSpace(rev); rev != "" {
+		if err := validBisectRef(rev); err != nil {
+			return BisectResult{}, err
+		}
+		args = append(args, rev)
+	}
+	return runBisect(repoPath, args...)
+}
+
+// ResetBisect ends the bisect session and returns to the original branch.
+func ResetBisect(repoPath string) (string, error) {
+	res, err := runBisect(repoPath, "reset")
+	return res.Output, err
+}
+
+// BisectLog returns the `git bisect log` of the current session, which can
+// be saved and replayed.
+func BisectLog(repoPath string) (string, error) {
+	res, err := runBisect(repoPath, "log")
+	return res.Output, err
+}
+
+// GetBisectState reports whether a bisect is in progress, its bounds, the
+// current candidate and how many steps remain.
+func GetBisectState(repoPath string) (BisectState, error) {
+	var st BisectState
+	if err := validateGitRepo(repoPath); err != nil {
+		return st, err
+	}
+	if !gitPathExists(repoPath, "BISECT_START") {
+		return st, nil
+	}
+	st.Active = true
+
+	refs := exec.Command("git", "-C", repoPath, "for-each-ref", "--format=%(refname) %(objectname)", "refs/bisect/")
+	hideWindow(refs)
+	out, err := refs.Output()
+	if err != nil {
+		return st, fmt.Errorf("reading bisect refs failed: %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		name, hash, _ := strings.Cut(line, " ")
+		switch {
+		case name == "refs/bisect/bad":
+			st.Bad = hash
+		case strings.HasPrefix(name, "refs/bisect/good-"):
+			st.Good = append(st.Good, hash)
+		}
+	}
+	if names, err := os.ReadFile(gitPath(repoPath, "BISECT_NAMES")); err == nil {
+		st.Paths = parseBisectNames(string(names))
+	}
+
+	if current, err := commitSummary(repoPath, "HEAD"); err == nil {
+		st.Current = current
+	}
+	if st.Bad == "" || len(st.Good) == 0 {
+		// Waiting for both a good and a bad revision.
+		return st, nil
+	}
+
+	args := []string{"-C", repoPath, "rev-list", "--bisect-vars", st.Bad}
+	for _, g := range st.Good {
+		args = append(args, "^"+g)
+	}
+	args = append(args, "--")
+	args = append(args, st.Paths...)
+	vars := exec.Command("git", args...)
+	hideWindow(vars)
+	out, err = vars.Output()
+	if err != nil {
+		return st, fmt.Errorf("computing bisect steps failed: %v", err)
+	}
+	v := parseBisectVars(string(out))
+	st.Remaining, _ = strconv.Atoi(v["bisect_all"])
+	st.Steps, _ = strconv.Atoi(v["bisect_steps"])
+	if log, err := os.ReadFile(gitPath(repoPath, "BISECT_LOG")); err == nil {
+		if hash := firstBadFromLog(string(log)); hash != "" {
+			if bad, err := commitSummary(repoPath, hash); err == nil {
+				st.FirstBad = &bad
+				st.Steps = 0
+			}
+		}
+	}
+	return st, nil
+}
+
+// firstBadFromLog returns the commit git recorded in BISECT_LOG as
+// "# first bad commit: [<hash>] <subject>" when the bisect ended, or "".
+func firstBadFromLog(log string) string {
+	hash := ""
+	for _, line := range strings.Split(log, "\n") {
+		rest, ok := strings.CutPrefix(line, "# first bad commit: [")
+		if !ok {
+			continue
+		}
+		if end := strings.Index(rest, "]"); end > 0 {
+			hash = rest[:end]
+		}
+	}
+	return hash
+}
+
+// parseBisectVars parses the shell assignments printed by
+// `git rev-list --bisect-vars`, e.g. "bisect_steps=3".
+func parseBisectVars(out string) map[string]string {
+	vars := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if ok {
+			vars[key] = strings.Trim(value, "'")
+		}
+	}
+	return vars
+}
+
+// parseBisectNames parses .git/BISECT_NAMES, which holds the arguments
+// given after "--" to `git bisect start`, shell-quoted by git: each one in
+// single quotes; a ' or ! inside is written by closing the quotes,
+// escaping it with a backslash and reopening them.
+func parseBisectNames(content string) []string {
+	var args []string
+	var cur strings.Builder
+	inArg, quoted := false, false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quoted:
+			if c == '\'' {
+				quoted = false
+			} else {
+				cur.WriteByte(c)
+			}
+		case c == '\'':
+			quoted, inArg = true, true
+		case c == '\\' && i+1 < len(content):
+			i++
+			cur.WriteByte(content[i])
+			inArg = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	return args
+}
+
+// commitSummary returns the CommitSummary of rev.
+func commitSummary(repoPath, rev string) (CommitSummary, error) {
+	cmd := exec.Command("git", "-C", repoPath, "log", "-1", "--format=%H%x00%h%x00%an%x00%ae%x00%at%x00%s", rev, "--")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return CommitSummary{}, fmt.Errorf("reading commit %s failed: %v", rev, err)
+	}
+	f := strings.SplitN(strings.TrimRight(string(out), "\n"), "\x00", 6)
+	if len(f) < 6 {
+		return CommitSummary{}, fmt.Errorf("unexpected log output for %s", rev)
+	}
+	return commitSummaryFields(f), nil
+}
+
+// BisectRun runs `git bisect run` with command, a shell command line whose
+// exit status decides each step: 0 good, 125 skip, 1-127 bad. Each line of
+// output is passed to onOutput as it is produced. Cancelling ctx stops the run.
+func BisectRun(ctx context.Context, repoPath, command string, onOutput func(line string)) (BisectResult, error) {
+	var res BisectResult
+	if err := validateGitRepo(repoPath); err != nil {
+		return res, err
+	}
+	if strings.TrimSpace(command) == "" {
+		return res, errors.New("a test command is required")
+	}
+	st, err := GetBisectState(repoPath)
+	if err != nil {
+		return res, err
+	}
+	if st.Bad == "" || len(st.Good) == 0 {
+		return res, errors.New("mark a good and a bad revision before running bisect")
+	}
+
+	shell := []string{"sh", "-c", command}
+	if runtime.GOOS == "windows" {
+		shell = []string{"cmd", "/C", command}
+	}
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repoPath, "bisect", "run"}, shell...)...)
+	hideWindow(cmd)
+	// Cancelling must also stop the test command git started, and not wait
+	// forever for grandchildren that still hold the output pipe.
+	killProcessTree(cmd)
+	cmd.WaitDelay = 5 * time.Second
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	var output strings.Builder
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			output.WriteString(line + "\n")
+			if onOutput != nil {
+				onOutput(line)
+			}
+		}
+		// Keep draining so git never blocks on a full pipe.
+		_, _ = io.Copy(io.Discard, pr)
+	}()
+	runErr := cmd.Run()
+	pw.Close()
+	<-done
+
+	res.Output = output.String()
+	if ctx.Err() != nil {
+		return res, ctx.Err()
+	}
+	res.State, err = GetBisectState(repoPath)
+	if runErr != nil {
+		return res, fmt.Errorf("bisect run failed: %v\n%s", runErr, res.Output)
+	}
+	return res, err
+}
@@ -0,0 +1,190 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// bisectTestRepo commits ten versions of value.txt, the sixth of which
+// introduces "bug", and returns the repository and the commit hashes.
+func bisectTestRepo(t *testing.T) (string, []string) {
+	dir := initTestRepo(t)
+	var hashes []string
+	for i := 1; i <= 10; i++ {
+		content := fmt.Sprintf("v%d\n", i)
+		if i >= 6 {
+			content += "bug\n"
+		}
+		if err := os.WriteFile(filepath.Join(dir, "value.txt"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if i%2 == 0 {
+			if err := os.WriteFile(filepath.Join(dir, "other.txt"), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		gitRun(t, dir, "add", ".")
+		gitRun(t, dir, "commit", "-q", "-m", fmt.Sprintf("change %d", i))
+		hashes = append(hashes, strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD")))
+	}
+	return dir, hashes
+}
+
+func TestBisectManual(t *testing.T) {
+	dir, hashes := bisectTestRepo(t)
+	if _, err := StartBisect(dir, "HEAD", []string{"--bad"}, nil); err == nil {
+		t.Fatal("expected option-like revision to be rejected")
+	}
+
+	res, err := StartBisect(dir, "HEAD", []string{hashes[0]}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.State.Active || res.State.Bad != hashes[9] || len(res.State.Good) != 1 {
+		t.Fatalf("unexpected state: %+v", res.State)
+	}
+	if res.State.Remaining != 9 || res.State.Steps == 0 || res.State.Current.Hash == "" {
+		t.Errorf("expected a candidate and a step estimate: %+v", res.State)
+	}
+	if _, err := StartBisect(dir, "HEAD", []string{hashes[0]}, nil); err == nil {
+		t.Error("expected a second start to fail")
+	}
+
+	for i := 0; i < 10 && res.State.FirstBad == nil; i++ {
+		data, err := os.ReadFile(filepath.Join(dir, "value.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		verdict := "good"
+		if strings.Contains(string(data), "bug") {
+			verdict = "bad"
+		}
+		if res, err = MarkBisect(dir, verdict, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if res.State.FirstBad == nil || res.State.FirstBad.Hash != hashes[5] {
+		t.Fatalf("expected %s as first bad commit: %+v", hashes[5], res.State)
+	}
+	if res.State.Steps != 0 {
+		t.Errorf("expected no steps left: %+v", res.State)
+	}
+
+	log, err := BisectLog(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(log, "git bisect start") || !strings.Contains(log, "git bisect bad") {
+		t.Errorf("unexpected log: %s", log)
+	}
+
+	if _, err := MarkBisect(dir, "maybe", ""); err == nil {
+		t.Error("expected unknown verdict to be rejected")
+	}
+	if _, err := ResetBisect(dir); err != nil {
+		t.Fatal(err)
+	}
+	st, err := GetBisectState(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Active {
+		t.Errorf("expected bisect to be reset: %+v", st)
+	}
+	if head := strings.TrimSpace(gitRun(t, dir, "rev-parse", "--abbrev-ref", "HEAD")); head != "main" {
+		t.Errorf("expected to be back on main, got %s", head)
+	}
+}
+
+func TestBisectPathsAndRun(t *testing.T) {
+	dir, hashes := bisectTestRepo(t)
+	res, err := StartBisect(dir, hashes[9], []string{hashes[0]}, []string{"other.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.State.Paths) != 1 || res.State.Paths[0] != "other.txt" {
+		t.Errorf("expected path restriction: %+v", res.State)
+	}
+	// Only the even-numbered commits touch other.txt.
+	if res.State.Remaining != 5 {
+		t.Errorf("expected 5 candidates, got %+v", res.State)
+	}
+
+	var lines []string
+	res, err = BisectRun(context.Background(), dir, "! grep -q bug value.txt", func(line string) {
+		lines = append(lines, line)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) == 0 || !strings.Contains(res.Output, "is the first bad commit") {
+		t.Errorf("expected streamed output: %q", res.Output)
+	}
+	if res.State.FirstBad == nil || res.State.FirstBad.Hash != hashes[5] {
+		t.Errorf("expected %s as first bad commit: %+v", hashes[5], res.State)
+	}
+	if _, err := ResetBisect(dir); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBisectRunRequiresBounds(t *testing.T) {
+	dir, _ := bisectTestRepo(t)
+	if _, err := BisectRun(context.Background(), dir, "true", nil); err == nil {
+		t.Error("expected bisect run without a session to fail")
+	}
+}
+
+func TestParseBisectNames(t *testing.T) {
+	got := parseBisectNames(` '--' 'my dir/a b.txt' 'it'\''s' 'wow'\!'' 'x'` + "\n")
+	want := []string{"my dir/a b.txt", "it's", "wow!", "x"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBisectPathWithSpaces(t *testing.T) {
+	dir, hashes := bisectTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "my notes.txt"), []byte("x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "notes")
+	res, err := StartBisect(dir, "HEAD", []string{hashes[0]}, []string{"my notes.txt", "other.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.State.Paths) != 2 || res.State.Paths[0] != "my notes.txt" {
+		t.Errorf("unexpected paths: %q", res.State.Paths)
+	}
+	// The notes commit plus the five even-numbered commits.
+	if res.State.Remaining != 6 {
+		t.Errorf("expected 6 candidates, got %+v", res.State)
+	}
+	if res.State.FirstBad != nil {
+		t.Errorf("first bad commit reported too early: %+v", res.State.FirstBad)
+	}
+}
+
+func TestBisectRunCancel(t *testing.T) {
+	dir, hashes := bisectTestRepo(t)
+	if _, err := StartBisect(dir, "HEAD", []string{hashes[0]}, nil); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := BisectRun(ctx, dir, "sleep 30; true", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the run to be cancelled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("test command kept running for %v after cancellation", elapsed)
+	}
+}
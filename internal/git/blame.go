@@ -0,0 +1,191 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultIgnoreRevsFile is the conventional file listing bulk-formatting
+// commits that blame should look past.
+const DefaultIgnoreRevsFile = ".git-blame-ignore-revs"
+
+// BlameOptions controls BlameDetails.
+type BlameOptions struct {
+	// Rev blames the file as of a revision instead of the working tree.
+	Rev string
+	// StartLine and EndLine limit the blame to a 1-based, inclusive line
+	// range (-L). Zero leaves the corresponding end open.
+	StartLine int
+	EndLine   int
+	// IgnoreWhitespace ignores whitespace-only changes (-w).
+	IgnoreWhitespace bool
+	// DetectMoves finds lines moved within a file (-M); DetectCopies also
+	// finds lines moved or copied from other files changed in the same
+	// commit (-C).
+	DetectMoves  bool
+	DetectCopies bool
+	// IgnoreRevsFile names a file of revisions to skip, relative to the
+	// repository root, e.g. DefaultIgnoreRevsFile.
+	IgnoreRevsFile string
+}
+
+// BlameCommit is the metadata of a commit referenced by blamed lines.
+type BlameCommit struct {
+	Hash           string
+	Author         string
+	AuthorEmail    string
+	AuthorTime     time.Time
+	Committer      string
+	CommitterEmail string
+	CommitterTime  time.Time
+	Summary        string
+	// Previous is the commit and path the line came from before this
+	// commit, as "hash path".
+	Previous string
+	// Boundary marks the oldest commit of a limited blame.
+	Boundary bool
+	// Uncommitted is set for lines changed in the working tree.
+	Uncommitted bool
+}
+
+// BlameLine is one line of a blamed file.
+type BlameLine struct {
+	Number int
+	// OrigNumber and OrigPath locate the line in Commit.
+	OrigNumber int
+	OrigPath   string
+	Commit     string
+	Content    string
+}
+
+// BlameResult is a parsed blame. Commits is keyed by hash so each commit's
+// metadata appears once however many lines it owns.
+type BlameResult struct {
+	Path    string
+	Lines   []BlameLine
+	Commits map[string]BlameCommit
+}
+
+// BlameDetails runs `git blame --line-porcelain` on file and returns its
+// lines with their commits.
+func BlameDetails(repoPath, file string, opts BlameOptions) (BlameResult, error) {
+	res := BlameResult{Path: file}
+	if err := validateGitRepo(repoPath); err != nil {
+		return res, err
+	}
+	if strings.TrimSpace(file) == "" {
+		return res, errors.New("file path cannot be empty")
+	}
+	args := []string{"-C", repoPath, "blame", "--line-porcelain"}
+	if opts.IgnoreWhitespace {
+		args = append(args, "-w")
+	}
+	if opts.DetectMoves {
+		args = append(args, "-M")
+	}
+	if opts.DetectCopies {
+		args = append(args, "-C")
+	}
+	if f := strings.TrimSpace(opts.IgnoreRevsFile); f != "" {
+		args = append(args, "--ignore-revs-file", f)
+	}
+	if opts.StartLine < 0 || opts.EndLine < 0 || (opts.EndLine > 0 && opts.EndLine < max(opts.StartLine, 1)) {
+		return res, fmt.Errorf("invalid line range %d-%d", opts.StartLine, opts.EndLine)
+	}
+	if opts.StartLine > 0 || opts.EndLine > 0 {
+		r := strconv.Itoa(max(opts.StartLine, 1)) + ","
+		if opts.EndLine > 0 {
+			r += strconv.Itoa(opts.EndLine)
+		}
+		args = append(args, "-L", r)
+	}
+	if rev := strings.TrimSpace(opts.Rev); rev != "" {
+		if strings.HasPrefix(rev, "-") {
+			return res, errors.New("invalid revision")
+		}
+		args = append(args, rev)
+	}
+	args = append(args, "--", file)
+
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		msg := ""
+		if ee, ok := err.(*exec.ExitError); ok {
+			msg = string(ee.Stderr)
+		}
+		return res, fmt.Errorf("blame failed: %v\n%s", err, msg)
+	}
+	res.Lines, res.Commits = parseLinePorcelain(string(out))
+	return res, nil
+}
+
+// parseLinePorcelain parses `git blame --line-porcelain` output, in which
+// every line carries a full header followed by the content after a tab.
+func parseLinePorcelain(out string) ([]BlameLine, map[string]BlameCommit) {
+	lines := []BlameLine{}
+	commits := make(map[string]BlameCommit)
+	var cur BlameLine
+	var commit BlameCommit
+	header := true
+	for _, raw := range strings.Split(out, "\n") {
+		if content, ok := strings.CutPrefix(raw, "\t"); ok {
+			cur.Content = content
+			lines = append(lines, cur)
+			if _, seen := commits[commit.Hash]; !seen {
+				commits[commit.Hash] = commit
+			}
+			header = true
+			continue
+		}
+		if header {
+			f := strings.Fields(raw)
+			if len(f) < 3 {
+				continue
+			}
+			cur = BlameLine{Commit: f[0]}
+			cur.OrigNumber, _ = strconv.Atoi(f[1])
+			cur.Number, _ = strconv.Atoi(f[2])
+			commit = BlameCommit{Hash: f[0], Uncommitted: strings.Trim(f[0], "0") == ""}
+			header = false
+			continue
+		}
+		key, value, _ := strings.Cut(raw, " ")
+		switch key {
+		case "author":
+			commit.Author = value
+		case "author-mail":
+			commit.AuthorEmail = strings.Trim(value, "<>")
+		case "author-time":
+			commit.AuthorTime = unixTime(value)
+		case "committer":
+			commit.Committer = value
+		case "committer-mail":
+			commit.CommitterEmail = strings.Trim(value, "<>")
+		case "committer-time":
+			commit.CommitterTime = unixTime(value)
+		case "summary":
+			commit.Summary = value
+		case "previous":
+			commit.Previous = value
+		case "boundary":
+			commit.Boundary = true
+		case "filename":
+			cur.OrigPath = value
+		}
+	}
+	return lines, commits
+}
+
+func unixTime(s string) time.Time {
+	secs, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
@@ -0,0 +1,72 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBlameDetails(t *testing.T) {
+	dir := initTestRepo(t)
+	first := strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD"))
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\nworld\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "commit", "-q", "-am", "add world")
+	second := strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD"))
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello  \nworld\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "commit", "-q", "-am", "reformat")
+	reformat := strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD"))
+
+	res, err := BlameDetails(dir, "README.md", BlameOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Lines) != 2 || len(res.Commits) != 2 {
+		t.Fatalf("unexpected blame: %+v", res)
+	}
+	if l := res.Lines[0]; l.Commit != reformat || l.Number != 1 || l.OrigNumber != 1 || l.OrigPath != "README.md" || l.Content != "hello  " {
+		t.Errorf("unexpected first line: %+v", l)
+	}
+	if c := res.Commits[second]; c.Summary != "add world" || c.Author == "" || c.AuthorTime.IsZero() || c.Previous == "" {
+		t.Errorf("unexpected commit metadata: %+v", c)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, DefaultIgnoreRevsFile), []byte(reformat+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	res, err = BlameDetails(dir, "README.md", BlameOptions{IgnoreRevsFile: DefaultIgnoreRevsFile, StartLine: 1, EndLine: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Lines) != 1 || res.Lines[0].Commit != first {
+		t.Errorf("expected the reformat commit to be skipped: %+v", res.Lines)
+	}
+
+	res, err = BlameDetails(dir, "README.md", BlameOptions{Rev: first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Lines) != 1 || res.Lines[0].Content != "hello" {
+		t.Errorf("unexpected blame at revision: %+v", res.Lines)
+	}
+
+	if _, err := BlameDetails(dir, "README.md", BlameOptions{StartLine: 3, EndLine: 2}); err == nil {
+		t.Error("expected an invalid range error")
+	}
+}
+
+func TestParseLinePorcelainUncommitted(t *testing.T) {
+	out := "0000000000000000000000000000000000000000 1 1 1\nauthor Not Committed Yet\nauthor-mail <not.committed.yet>\nauthor-time 1700000000\nsummary Version of a.txt from a.txt\nfilename a.txt\n\tnew line\n"
+	lines, commits := parseLinePorcelain(out)
+	if len(lines) != 1 || lines[0].Content != "new line" {
+		t.Fatalf("unexpected lines: %+v", lines)
+	}
+	c := commits[lines[0].Commit]
+	if !c.Uncommitted || c.AuthorEmail != "not.committed.yet" || c.AuthorTime.Unix() != 1700000000 {
+		t.Errorf("unexpected commit: %+v", c)
+	}
+}
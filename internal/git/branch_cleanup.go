@@ -0,0 +1,194 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// backupRefPrefix is where DeleteBranches keeps a ref to every deleted branch
+// tip. The refs are created with a reflog so repeated deletions of the same
+// name remain recoverable.
+const backupRefPrefix = "refs/gitscope-backup/"
+
+// StaleBranch is a local branch reported by AnalyzeStaleBranches together
+// with the reasons it was flagged.
+type StaleBranch struct {
+	Branch         BranchInfo
+	MergedIntoBase bool
+	UpstreamGone   bool
+	Inactive       bool
+	AgeDays        int
+}
+
+// DeletedBranch records a branch removed by DeleteBranches.
+type DeletedBranch struct {
+	Name      string
+	Hash      string
+	BackupRef string
+}
+
+// BranchCleanupResult summarises a bulk branch deletion.
+type BranchCleanupResult struct {
+	Deleted []DeletedBranch
+	Failed  map[string]string
+}
+
+// AnalyzeStaleBranches lists local branches that are merged into base, whose
+// upstream has been deleted, or whose last commit is older than staleDays.
+// A staleDays value of zero or less disables the inactivity check. The
+// current branch and base itself are never reported.
+func AnalyzeStaleBranches(repoPath, base string, staleDays int) ([]StaleBranch, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	base = strings.TrimSpace(base)
+	if base == "" || strings.HasPrefix(base, "-") {
+		return nil, errors.New("invalid base branch")
+	}
+
+	branches, err := ListBranches(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "for-each-ref", "--merged", base, "--format=%(refname)", "refs/heads")
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("checking merged branches failed: %v\n%s", err, string(out))
+	}
+	merged := make(map[string]bool)
+	for _, ref := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		merged[ref] = true
+	}
+
+	return classifyStaleBranches(branches, merged, base, staleDays, time.Now()), nil
+}
+
+// classifyStaleBranches applies the stale branch rules to already listed branches.
+func classifyStaleBranches(branches []BranchInfo, merged map[string]bool, base string, staleDays int, now time.Time) []StaleBranch {
+	var stale []StaleBranch
+	for _, b := range branches {
+		if b.Remote || b.Current || b.Name == base {
+			continue
+		}
+		s := StaleBranch{
+			Branch:         b,
+			MergedIntoBase: merged[b.Ref],
+			UpstreamGone:   b.UpstreamGone,
+		}
+		if !b.LastCommit.IsZero() {
+			s.AgeDays = int(now.Sub(b.LastCommit).Hours() / 24)
+			s.Inactive = staleDays > 0 && s.AgeDays >= staleDays
+		}
+		if s.MergedIntoBase || s.UpstreamGone || s.Inactive {
+			stale = append(stale, s)
+		}
+	}
+	return stale
+}
+
+// DeleteBranches force-deletes the given local branches. Before each deletion
+// the branch tip is saved under refs/gitscope-backup/ so it can be brought
+// back with RestoreDeletedBranch. Failures are collected per branch rather
+// than aborting the whole batch.
+func DeleteBranches(repoPath string, names []string) (BranchCleanupResult, error) {
+	result := BranchCleanupResult{Failed: make(map[string]string)}
+	if err := validateGitRepo(repoPath); err != nil {
+		return result, err
+	}
+	if len(names) == 0 {
+		return result, errors.New("no branches selected")
+	}
+
+	currentCmd := exec.Command("git", "-C", repoPath, "branch", "--show-current")
+	hideWindow(currentCmd)
+	currentOut, _ := currentCmd.Output()
+	current := strings.TrimSpace(string(currentOut))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == current {
+			result.Failed[name] = "cannot delete the checked-out branch"
+			continue
+		}
+
+		revCmd := exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", "refs/heads/"+name)
+		hideWindow(revCmd)
+		revOut, err := revCmd.Output()
+		if err != nil {
+			result.Failed[name] = "branch not found"
+			continue
+		}
+		hash := strings.TrimSpace(string(revOut))
+
+		backupRef := backupRefPrefix + name
+		backupCmd := exec.Command("git", "-C", repoPath, "update-ref", "--create-reflog", "-m", "gitscope: backup before deleting "+name, backupRef, hash)
+		hideWindow(backupCmd)
+		if out, err := backupCmd.CombinedOutput(); err != nil {
+			result.Failed[name] = fmt.Sprintf("creating backup ref failed: %v %s", err, strings.TrimSpace(string(out)))
+			continue
+		}
+
+		delCmd := exec.Command("git", "-C", repoPath, "branch", "-D", "--", name)
+		hideWindow(delCmd)
+		if out, err := delCmd.CombinedOutput(); err != nil {
+			result.Failed[name] = fmt.Sprintf("%v %s", err, strings.TrimSpace(string(out)))
+			continue
+		}
+		result.Deleted = append(result.Deleted, DeletedBranch{Name: name, Hash: hash, BackupRef: backupRef})
+	}
+	return result, nil
+}
+
+// RestoreDeletedBranch recreates a branch removed by DeleteBranches from its backup ref.
+func RestoreDeletedBranch(repoPath, name string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || strings.HasPrefix(name, "-") {
+		return "", errors.New("invalid branch name")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "branch", name, backupRefPrefix+name)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("restoring branch failed: %v\n%s", err, string(out))
+	}
+	return "Restored branch " + name, nil
+}
+
+// PruneRemoteBranches removes remote-tracking refs whose branches no longer
+// exist on remote. An empty remote prunes all remotes.
+func PruneRemoteBranches(repoPath, remote string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	args := []string{"-C", repoPath, "fetch", "--prune"}
+	remote = strings.TrimSpace(remote)
+	switch {
+	case remote == "":
+		args = append(args, "--all")
+	case strings.HasPrefix(remote, "-"):
+		return "", errors.New("invalid remote name")
+	default:
+		args = append(args, remote)
+	}
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("pruning remote branches failed: %v\n%s", err, string(out))
+	}
+	if len(out) == 0 {
+		return "No stale remote-tracking branches found.", nil
+	}
+	return string(out), nil
+}
@@ -0,0 +1,57 @@
+package git
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClassifyStaleBranches(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	branches := []BranchInfo{
+		{Name: "main", Ref: "refs/heads/main", Current: true, LastCommit: now},
+		{Name: "done", Ref: "refs/heads/done", LastCommit: now},
+		{Name: "gone", Ref: "refs/heads/gone", UpstreamGone: true, LastCommit: now},
+		{Name: "old", Ref: "refs/heads/old", LastCommit: now.Add(-100 * 24 * time.Hour)},
+		{Name: "fresh", Ref: "refs/heads/fresh", LastCommit: now},
+		{Name: "origin/done", Ref: "refs/remotes/origin/done", Remote: true, LastCommit: now},
+	}
+	merged := map[string]bool{"refs/heads/main": true, "refs/heads/done": true}
+
+	stale := classifyStaleBranches(branches, merged, "main", 90, now)
+	if len(stale) != 3 {
+		t.Fatalf("expected 3 stale branches, got %d: %+v", len(stale), stale)
+	}
+	if s := stale[0]; s.Branch.Name != "done" || !s.MergedIntoBase {
+		t.Errorf("unexpected first entry: %+v", s)
+	}
+	if s := stale[1]; s.Branch.Name != "gone" || !s.UpstreamGone {
+		t.Errorf("unexpected second entry: %+v", s)
+	}
+	if s := stale[2]; s.Branch.Name != "old" || !s.Inactive || s.AgeDays != 100 {
+		t.Errorf("unexpected third entry: %+v", s)
+	}
+}
+
+func TestDeleteBranchesKeepsBackup(t *testing.T) {
+	dir := initTestRepo(t)
+	gitRun(t, dir, "branch", "topic")
+
+	result, err := DeleteBranches(dir, []string{"topic", "main", "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0].Name != "topic" {
+		t.Fatalf("unexpected deleted list: %+v", result.Deleted)
+	}
+	if _, ok := result.Failed["main"]; !ok {
+		t.Error("expected checked-out branch to be refused")
+	}
+	if _, ok := result.Failed["missing"]; !ok {
+		t.Error("expected missing branch to fail")
+	}
+
+	if _, err := RestoreDeletedBranch(dir, "topic"); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	gitRun(t, dir, "rev-parse", "--verify", "refs/heads/topic")
+}
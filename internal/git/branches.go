@@ -0,0 +1,199 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BranchInfo describes a local or remote-tracking branch.
+type BranchInfo struct {
+	Name         string
+	Ref          string
+	Remote       bool
+	Current      bool
+	Hash         string
+	Upstream     string
+	UpstreamGone bool
+	Ahead        int
+	Behind       int
+	LastCommit   time.Time
+	LastAuthor   string
+	Subject      string
+	Merged       bool
+}
+
+// branchFormat is the for-each-ref format used by ListBranches. Fields are
+// separated by NUL so subjects and author names can contain any text.
+const branchFormat = "%(refname)%00%(refname:short)%00%(HEAD)%00%(objectname:short)%00" +
+	"%(upstream:short)%00%(upstream:track)%00%(committerdate:unix)%00%(authorname)%00%(subject)"
+
+// ListBranches returns typed information for every local and remote-tracking branch.
+func ListBranches(repoPath string) ([]BranchInfo, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("git", "-C", repoPath, "for-each-ref", "--format="+branchFormat, "refs/heads", "refs/remotes")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing branches failed: %v", err)
+	}
+	branches := parseBranches(string(out))
+
+	// An unborn HEAD has nothing to be merged into, so failures are not fatal.
+	mergedCmd := exec.Command("git", "-C", repoPath, "for-each-ref", "--merged", "HEAD", "--format=%(refname)", "refs/heads", "refs/remotes")
+	hideWindow(mergedCmd)
+	if mergedOut, err := mergedCmd.Output(); err == nil {
+		merged := make(map[string]bool)
+		for _, ref := range strings.Split(strings.TrimSpace(string(mergedOut)), "\n") {
+			merged[ref] = true
+		}
+		for i := range branches {
+			branches[i].Merged = merged[branches[i].Ref] && !branches[i].Current
+		}
+	}
+	return branches, nil
+}
+
+// parseBranches converts for-each-ref output produced with branchFormat.
+func parseBranches(out string) []BranchInfo {
+	var branches []BranchInfo
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 9 {
+			continue
+		}
+		ref := fields[0]
+		// Skip symbolic refs such as refs/remotes/origin/HEAD.
+		if strings.HasPrefix(ref, "refs/remotes/") && strings.HasSuffix(ref, "/HEAD") {
+			continue
+		}
+		info := BranchInfo{
+			Name:       fields[1],
+			Ref:        ref,
+			Remote:     strings.HasPrefix(ref, "refs/remotes/"),
+			Current:    fields[2] == "*",
+			Hash:       fields[3],
+			Upstream:   fields[4],
+			LastAuthor: fields[7],
+			Subject:    fields[8],
+		}
+		info.Ahead, info.Behind, info.UpstreamGone = parseTrack(fields[5])
+		if secs, err := strconv.ParseInt(fields[6], 10, 64); err == nil {
+			info.LastCommit = time.Unix(secs, 0)
+		}
+		branches = append(branches, info)
+	}
+	return branches
+}
+
+// parseTrack reads %(upstream:track) values like "[ahead 2, behind 1]" or "[gone]".
+func parseTrack(track string) (ahead, behind int, gone bool) {
+	track = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(track), "["), "]")
+	if track == "gone" {
+		return 0, 0, true
+	}
+	for _, part := range strings.Split(track, ",") {
+		part = strings.TrimSpace(part)
+		if n, ok := strings.CutPrefix(part, "ahead "); ok {
+			ahead, _ = strconv.Atoi(n)
+		} else if n, ok := strings.CutPrefix(part, "behind "); ok {
+			behind, _ = strconv.Atoi(n)
+		}
+	}
+	return ahead, behind, false
+}
+
+// SetUpstream configures branch to track upstream (e.g. "origin/main").
+func SetUpstream(repoPath, branch, upstream string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	branch = strings.TrimSpace(branch)
+	upstream = strings.TrimSpace(upstream)
+	if branch == "" || upstream == "" {
+		return "", errors.New("branch and upstream cannot be empty")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "branch", "--set-upstream-to="+upstream, "--", branch)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("setting upstream failed: %v\n%s", err, string(out))
+	}
+	return "Branch " + branch + " now tracks " + upstream, nil
+}
+
+// UnsetUpstream removes the tracking configuration of branch.
+func UnsetUpstream(repoPath, branch string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	branch = strings.TrimSpace(branch)
+	if branch == "" {
+		return "", errors.New("branch name cannot be empty")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "branch", "--unset-upstream", "--", branch)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("unsetting upstream failed: %v\n%s", err, string(out))
+	}
+	return "Removed upstream of " + branch, nil
+}
+
+// CheckoutRemoteBranch creates a local branch tracking remoteBranch (e.g.
+// "origin/feature") and switches to it. An empty localName uses the remote
+// branch name without its remote prefix.
+func CheckoutRemoteBranch(repoPath, remoteBranch, localName string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	remoteBranch = strings.TrimSpace(remoteBranch)
+	if remoteBranch == "" || strings.HasPrefix(remoteBranch, "-") {
+		return "", errors.New("invalid remote branch name")
+	}
+	localName = strings.TrimSpace(localName)
+	if localName == "" {
+		_, after, ok := strings.Cut(remoteBranch, "/")
+		if !ok || after == "" {
+			return "", errors.New("remote branch must be of the form <remote>/<branch>")
+		}
+		localName = after
+	}
+	if strings.HasPrefix(localName, "-") {
+		return "", errors.New("invalid local branch name")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "switch", "-c", localName, "--track", remoteBranch)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("checking out remote branch failed: %v\n%s", err, string(out))
+	}
+	return "Switched to new branch " + localName + " tracking " + remoteBranch, nil
+}
+
+// DeleteRemoteBranch deletes branch on the given remote.
+func DeleteRemoteBranch(repoPath, remote, branch string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	remote = strings.TrimSpace(remote)
+	branch = strings.TrimSpace(branch)
+	if remote == "" || branch == "" {
+		return "", errors.New("remote and branch cannot be empty")
+	}
+	if strings.HasPrefix(remote, "-") || strings.HasPrefix(branch, "-") {
+		return "", errors.New("invalid remote or branch name")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "push", remote, "--delete", branch)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("deleting remote branch failed: %v\n%s", err, string(out))
+	}
+	return "Deleted " + remote + "/" + branch, nil
+}
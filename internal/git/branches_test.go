@@ -0,0 +1,93 @@
+package git
+
+import "testing"
+
+func TestParseTrack(t *testing.T) {
+	cases := []struct {
+		in            string
+		ahead, behind int
+		gone          bool
+	}{
+		{"", 0, 0, false},
+		{"[ahead 2]", 2, 0, false},
+		{"[behind 3]", 0, 3, false},
+		{"[ahead 1, behind 4]", 1, 4, false},
+		{"[gone]", 0, 0, true},
+	}
+	for _, c := range cases {
+		ahead, behind, gone := parseTrack(c.in)
+		if ahead != c.ahead || behind != c.behind || gone != c.gone {
+			t.Errorf("parseTrack(%q) = %d, %d, %v; want %d, %d, %v", c.in, ahead, behind, gone, c.ahead, c.behind, c.gone)
+		}
+	}
+}
+
+func TestParseBranches(t *testing.T) {
+	out := "refs/heads/main\x00main\x00*\x00abc1234\x00origin/main\x00[ahead 1]\x001700000000\x00Alice\x00fix things\n" +
+		"refs/heads/old\x00old\x00 \x00def5678\x00origin/old\x00[gone]\x001600000000\x00Bob\x00old work\n" +
+		"refs/remotes/origin/HEAD\x00origin\x00 \x00abc1234\x00\x00\x001700000000\x00Alice\x00fix things\n" +
+		"refs/remotes/origin/main\x00origin/main\x00 \x00abc1234\x00\x00\x001700000000\x00Alice\x00fix things\n"
+	branches := parseBranches(out)
+	if len(branches) != 3 {
+		t.Fatalf("expected 3 branches, got %d: %+v", len(branches), branches)
+	}
+	if b := branches[0]; !b.Current || b.Remote || b.Ahead != 1 || b.Upstream != "origin/main" || b.LastAuthor != "Alice" {
+		t.Errorf("unexpected main: %+v", b)
+	}
+	if b := branches[1]; !b.UpstreamGone || b.LastCommit.Unix() != 1600000000 {
+		t.Errorf("unexpected old: %+v", b)
+	}
+	if b := branches[2]; !b.Remote || b.Name != "origin/main" {
+		t.Errorf("unexpected remote branch: %+v", b)
+	}
+}
+
+func TestListBranchesMerged(t *testing.T) {
+	dir := initTestRepo(t)
+	gitRun(t, dir, "branch", "merged-topic")
+	gitRun(t, dir, "switch", "-c", "unmerged-topic")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "topic work")
+	gitRun(t, dir, "switch", "main")
+
+	branches, err := ListBranches(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]BranchInfo)
+	for _, b := range branches {
+		byName[b.Name] = b
+	}
+	if !byName["main"].Current || byName["main"].Merged {
+		t.Errorf("unexpected main: %+v", byName["main"])
+	}
+	if !byName["merged-topic"].Merged {
+		t.Errorf("expected merged-topic to be merged: %+v", byName["merged-topic"])
+	}
+	if byName["unmerged-topic"].Merged {
+		t.Errorf("expected unmerged-topic to be unmerged: %+v", byName["unmerged-topic"])
+	}
+}
+
+func TestCreateBranchWithOptions(t *testing.T) {
+	dir := initTestRepo(t)
+	gitRun(t, dir, "tag", "v1")
+
+	if _, err := CreateBranch(dir, "plain"); err != nil {
+		t.Fatalf("CreateBranch without a remote should succeed: %v", err)
+	}
+	if _, err := CreateBranchWithOptions(dir, "from-tag", BranchOptions{StartPoint: "v1", Checkout: true}); err != nil {
+		t.Fatal(err)
+	}
+	if cur := gitRun(t, dir, "branch", "--show-current"); cur != "from-tag\n" {
+		t.Errorf("expected to be on from-tag, got %q", cur)
+	}
+	if _, err := CreateBranchWithOptions(dir, "bad..name", BranchOptions{}); err == nil {
+		t.Error("expected invalid branch name to be rejected")
+	}
+	if _, err := CreateBranchWithOptions(dir, "orphan", BranchOptions{Orphan: true, StartPoint: "v1"}); err == nil {
+		t.Error("expected orphan with start point to be rejected")
+	}
+	if _, err := CreateBranchWithOptions(dir, "orphan", BranchOptions{Orphan: true}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,456 @@
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangelogFile is the file GenerateChangelog prepends release notes to.
+const ChangelogFile = "CHANGELOG.md"
+
+// ChangelogOptions controls GenerateChangelog.
+type ChangelogOptions struct {
+	// From is the exclusive start of the range. Empty means the most recent
+	// tag reachable from To, or the whole history when there is none.
+	From string
+	// FullHistory starts the range at the root commit when From is empty
+	// instead of at the most recent tag.
+	FullHistory bool
+	// To is the inclusive end of the range. Empty means HEAD.
+	To string
+	// Format is "markdown" (default), "keepachangelog" or "json".
+	Format string
+	// Version is used as the release heading and tag name. Empty renders
+	// the notes as "Unreleased".
+	Version string
+	// Prepend inserts the rendered notes into CHANGELOG.md. When combined
+	// with Tag the file is committed before tagging.
+	Prepend bool
+	// Tag creates an annotated tag named Version whose message is the notes.
+	Tag bool
+}
+
+// ChangelogEntry is one commit in a changelog.
+type ChangelogEntry struct {
+	Hash         string
+	Type         string
+	Scope        string
+	Subject      string
+	Author       string
+	Date         time.Time
+	Breaking     bool
+	BreakingNote string
+	PR           int
+}
+
+// Changelog is the result of GenerateChangelog.
+type Changelog struct {
+	Version string
+	From    string
+	To      string
+	Date    time.Time
+	Entries []ChangelogEntry
+	// Rendered is the changelog in the requested format. It is left out of
+	// the "json" format, which is rendered before it is set.
+	Rendered string `json:",omitempty"`
+}
+
+var (
+	prSuffix    = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+	mergePRLine = regexp.MustCompile(`^Merge pull request #(\d+)`)
+	// footerToken matches the start of a Conventional Commits footer.
+	footerToken = regexp.MustCompile(`^(?:[A-Za-z-]+|BREAKING CHANGE)(?:: | #)`)
+)
+
+// changelogSections orders commit types in rendered notes.
+var changelogSections = []struct{ typ, title string }{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"revert", "Reverts"},
+	{"docs", "Documentation"},
+	{"", "Other Changes"},
+}
+
+// GenerateChangelog collects commits in From..To and renders release notes.
+func GenerateChangelog(repoPath string, opts ChangelogOptions) (Changelog, error) {
+	var cl Changelog
+	if err := validateGitRepo(repoPath); err != nil {
+		return cl, err
+	}
+	to := strings.TrimSpace(opts.To)
+	if to == "" {
+		to = "HEAD"
+	}
+	from := strings.TrimSpace(opts.From)
+	if strings.HasPrefix(from, "-") || strings.HasPrefix(to, "-") {
+		return cl, errors.New("invalid revision range")
+	}
+	if from == "" && !opts.FullHistory {
+		from = lastTag(repoPath, to)
+	}
+	format := opts.Format
+	if format == "" {
+		format = "markdown"
+	}
+	if format != "markdown" && format != "keepachangelog" && format != "json" {
+		return cl, fmt.Errorf("unknown changelog format: %s", format)
+	}
+	if opts.Prepend && format == "json" {
+		return cl, errors.New("JSON output cannot be written to " + ChangelogFile)
+	}
+	if opts.Tag && strings.TrimSpace(opts.Version) == "" {
+		return cl, errors.New("a version is required to create a release tag")
+	}
+	if opts.Tag {
+		if opts.Prepend && to != "HEAD" {
+			return cl, errors.New("committing " + ChangelogFile + " before tagging requires the range to end at HEAD")
+		}
+		if err := validateTagName(repoPath, strings.TrimSpace(opts.Version)); err != nil {
+			return cl, err
+		}
+		if tagExists(repoPath, strings.TrimSpace(opts.Version)) {
+			return cl, fmt.Errorf("tag %s already exists", strings.TrimSpace(opts.Version))
+		}
+	}
+
+	entries, err := changelogEntries(repoPath, from, to)
+	if err != nil {
+		return cl, err
+	}
+	cl = Changelog{
+		Version: strings.TrimSpace(opts.Version),
+		From:    from,
+		To:      to,
+		Date:    time.Now(),
+		Entries: entries,
+	}
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(cl, "", "  ")
+		if err != nil {
+			return cl, err
+		}
+		cl.Rendered = string(data)
+	case "keepachangelog":
+		cl.Rendered = renderKeepAChangelog(cl)
+	default:
+		cl.Rendered = renderMarkdownChangelog(cl)
+	}
+
+	if opts.Prepend {
+		if err := prependChangelog(repoPath, cl.Rendered, format == "keepachangelog"); err != nil {
+			return cl, err
+		}
+		if opts.Tag {
+			if err := commitChangelog(repoPath, cl.Version); err != nil {
+				return cl, err
+			}
+		}
+	}
+	if opts.Tag {
+		target := to
+		if opts.Prepend {
+			target = "HEAD"
+		}
+		if _, err := CreateTag(repoPath, cl.Version, TagOptions{Message: renderMarkdownChangelog(cl), Target: target}); err != nil {
+			return cl, err
+		}
+	}
+	return cl, nil
+}
+
+// lastTag returns the most recent tag reachable from rev, or "" if there is none.
+func lastTag(repoPath, rev string) string {
+	cmd := exec.Command("git", "-C", repoPath, "describe", "--tags", "--abbrev=0", rev)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// tagExists reports whether refs/tags/name exists.
+func tagExists(repoPath, name string) bool {
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", "refs/tags/"+name)
+	hideWindow(cmd)
+	return cmd.Run() == nil
+}
+
+// changelogEntries lists the commits in from..to, or all commits reachable
+// from to when from is empty. Merge commits are left out, but a "Merge pull
+// request #N" merge attaches N to the commits it brought in.
+func changelogEntries(repoPath, from, to string) ([]ChangelogEntry, error) {
+	rangeArg := to
+	if from != "" {
+		rangeArg = from + ".." + to
+	}
+	cmd := exec.Command("git", "-C", repoPath, "log", "--format=%H%x00%P%x00%an%x00%ct%x00%B%x1e", rangeArg, "--")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading history for %s failed: %v", rangeArg, err)
+	}
+	commits := parseChangelogLog(string(out))
+
+	prs := make(map[string]int)
+	for _, c := range commits {
+		if len(c.parents) < 2 || c.PR == 0 || !mergePRLine.MatchString(c.Subject) {
+			continue
+		}
+		merged := exec.Command("git", "-C", repoPath, "rev-list", c.parents[0]+".."+c.parents[1])
+		hideWindow(merged)
+		out, err := merged.Output()
+		if err != nil {
+			return nil, fmt.Errorf("listing commits of pull request #%d failed: %v", c.PR, err)
+		}
+		for _, hash := range strings.Fields(string(out)) {
+			// Nested merges: the innermost pull request wins.
+			if _, ok := prs[hash]; !ok {
+				prs[hash] = c.PR
+			}
+		}
+	}
+
+	var entries []ChangelogEntry
+	for _, c := range commits {
+		if len(c.parents) > 1 {
+			continue
+		}
+		if c.PR == 0 {
+			c.PR = prs[c.Hash]
+		}
+		entries = append(entries, c.ChangelogEntry)
+	}
+	return entries, nil
+}
+
+// loggedCommit is a changelog entry with the parents it was logged with.
+type loggedCommit struct {
+	ChangelogEntry
+	parents []string
+}
+
+// parseChangelogLog converts log output produced by changelogEntries.
+func parseChangelogLog(out string) []loggedCommit {
+	var commits []loggedCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		fields := strings.SplitN(record, "\x00", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		entry := parseConventionalCommit(fields[4])
+		entry.Hash = fields[0]
+		entry.Author = fields[2]
+		if secs, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+			entry.Date = time.Unix(secs, 0)
+		}
+		commits = append(commits, loggedCommit{ChangelogEntry: entry, parents: strings.Fields(fields[1])})
+	}
+	return commits
+}
+
+// parseConventionalCommit extracts the type, scope, subject, breaking
+// change note and pull request number from a commit message.
+func parseConventionalCommit(msg string) ChangelogEntry {
+	var entry ChangelogEntry
+	msg = strings.TrimSpace(strings.ReplaceAll(msg, "\r\n", "\n"))
+	header, body, _ := strings.Cut(msg, "\n")
+	if m := mergePRLine.FindStringSubmatch(header); m != nil {
+		entry.PR, _ = strconv.Atoi(m[1])
+	}
+	if m := prSuffix.FindStringSubmatch(header); m != nil {
+		entry.PR, _ = strconv.Atoi(m[1])
+		header = strings.TrimSpace(header[:len(header)-len(m[0])])
+	}
+	entry.Subject = header
+	if m := conventionalHeader.FindStringSubmatch(header); m != nil {
+		entry.Type = strings.ToLower(m[1])
+		entry.Scope = m[2]
+		entry.Breaking = m[3] == "!"
+		entry.Subject = strings.TrimSpace(m[4])
+	}
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		for _, key := range []string{"BREAKING CHANGE:", "BREAKING-CHANGE:"} {
+			note, ok := strings.CutPrefix(line, key)
+			if !ok {
+				continue
+			}
+			// The note runs to the end of its paragraph or the next footer.
+			paragraph := []string{strings.TrimSpace(note)}
+			for _, next := range lines[i+1:] {
+				if strings.TrimSpace(next) == "" || footerToken.MatchString(next) {
+					break
+				}
+				paragraph = append(paragraph, strings.TrimSpace(next))
+			}
+			entry.Breaking = true
+			entry.BreakingNote = strings.TrimSpace(strings.Join(paragraph, "\n"))
+		}
+	}
+	return entry
+}
+
+// changelogLine formats an entry as a Markdown list item.
+func changelogLine(e ChangelogEntry) string {
+	var b strings.Builder
+	b.WriteString("- ")
+	if e.Scope != "" {
+		fmt.Fprintf(&b, "**%s:** ", e.Scope)
+	}
+	b.WriteString(e.Subject)
+	if e.PR > 0 {
+		fmt.Fprintf(&b, " (#%d)", e.PR)
+	}
+	if len(e.Hash) >= 7 {
+		fmt.Fprintf(&b, " (%s)", e.Hash[:7])
+	}
+	return b.String()
+}
+
+func changelogHeading(cl Changelog) string {
+	if cl.Version == "" {
+		return "Unreleased"
+	}
+	return cl.Version
+}
+
+// renderMarkdownChangelog groups entries by Conventional Commit type.
+func renderMarkdownChangelog(cl Changelog) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s (%s)\n", changelogHeading(cl), cl.Date.Format("2006-01-02"))
+
+	var breaking []string
+	for _, e := range cl.Entries {
+		if e.Breaking {
+			note := strings.ReplaceAll(e.BreakingNote, "\n", " ")
+			if note == "" {
+				note = e.Subject
+			}
+			breaking = append(breaking, changelogLine(ChangelogEntry{Scope: e.Scope, Subject: note, PR: e.PR, Hash: e.Hash}))
+		}
+	}
+	if len(breaking) > 0 {
+		b.WriteString("\n### ⚠ BREAKING CHANGES\n\n")
+		b.WriteString(strings.Join(breaking, "\n") + "\n")
+	}
+
+	known := make(map[string]bool)
+	for _, s := range changelogSections {
+		known[s.typ] = true
+	}
+	for _, s := range changelogSections {
+		var lines []string
+		for _, e := range cl.Entries {
+			if e.Type == s.typ || (s.typ == "" && !known[e.Type]) {
+				lines = append(lines, changelogLine(e))
+			}
+		}
+		if len(lines) > 0 {
+			fmt.Fprintf(&b, "\n### %s\n\n%s\n", s.title, strings.Join(lines, "\n"))
+		}
+	}
+	if len(cl.Entries) == 0 {
+		b.WriteString("\nNo changes.\n")
+	}
+	return b.String()
+}
+
+// renderKeepAChangelog renders entries using the Keep a Changelog sections.
+func renderKeepAChangelog(cl Changelog) string {
+	var b strings.Builder
+	if cl.Version == "" {
+		b.WriteString("## [Unreleased]\n")
+	} else {
+		fmt.Fprintf(&b, "## [%s] - %s\n", strings.TrimPrefix(cl.Version, "v"), cl.Date.Format("2006-01-02"))
+	}
+	sections := []struct {
+		title string
+		match func(ChangelogEntry) bool
+	}{
+		{"Added", func(e ChangelogEntry) bool { return e.Type == "feat" }},
+		{"Fixed", func(e ChangelogEntry) bool { return e.Type == "fix" }},
+		{"Changed", func(e ChangelogEntry) bool { return e.Type != "feat" && e.Type != "fix" }},
+	}
+	for _, s := range sections {
+		var lines []string
+		for _, e := range cl.Entries {
+			if !s.match(e) {
+				continue
+			}
+			line := changelogLine(e)
+			if e.Breaking {
+				line = "- **BREAKING:** " + strings.TrimPrefix(line, "- ")
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) > 0 {
+			fmt.Fprintf(&b, "\n### %s\n\n%s\n", s.title, strings.Join(lines, "\n"))
+		}
+	}
+	return b.String()
+}
+
+// keepAChangelogHeader starts a new CHANGELOG.md in Keep a Changelog format.
+const keepAChangelogHeader = "# Changelog\n\nAll notable changes to this project will be documented in this file.\n\n" +
+	"The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),\n" +
+	"and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).\n"
+
+// prependChangelog inserts notes above the newest release in CHANGELOG.md,
+// keeping any title or introduction at the top of the file.
+func prependChangelog(repoPath, notes string, keepAChangelog bool) error {
+	path := filepath.Join(repoPath, ChangelogFile)
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	content := string(existing)
+	if content == "" {
+		if keepAChangelog {
+			content = keepAChangelogHeader
+		} else {
+			content = "# Changelog\n"
+		}
+	}
+	return os.WriteFile(path, []byte(insertChangelogNotes(content, notes)), 0644)
+}
+
+// insertChangelogNotes places notes before the first "## " heading of
+// content, or at the end when there is none.
+func insertChangelogNotes(content, notes string) string {
+	notes = strings.TrimRight(notes, "\n") + "\n"
+	if strings.HasPrefix(content, "## ") {
+		return notes + "\n" + content
+	}
+	if i := strings.Index(content, "\n## "); i >= 0 {
+		return content[:i+1] + notes + "\n" + content[i+1:]
+	}
+	return strings.TrimRight(content, "\n") + "\n\n" + notes
+}
+
+// commitChangelog commits CHANGELOG.md on its own as the release commit.
+func commitChangelog(repoPath, version string) error {
+	add := exec.Command("git", "-C", repoPath, "add", "--", ChangelogFile)
+	hideWindow(add)
+	if out, err := add.CombinedOutput(); err != nil {
+		return fmt.Errorf("staging %s failed: %v\n%s", ChangelogFile, err, string(out))
+	}
+	cmd := exec.Command("git", "-C", repoPath, "commit", "-m", "chore(release): "+version, "--", ChangelogFile)
+	hideWindow(cmd)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("committing %s failed: %v\n%s", ChangelogFile, err, string(out))
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package git
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	e := parseConventionalCommit("feat(api)!: drop v1 endpoints (#123)\n\nBREAKING CHANGE: clients must use /v2\n")
+	if e.Type != "feat" || e.Scope != "api" || !e.Breaking || e.PR != 123 || e.Subject != "drop v1 endpoints" || e.BreakingNote != "clients must use /v2" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	e = parseConventionalCommit("feat: new config\n\nBREAKING CHANGE: the config file moved\nto ~/.config/app.\nRefs: #4\n\nMore body.")
+	if e.BreakingNote != "the config file moved\nto ~/.config/app." {
+		t.Errorf("expected the whole breaking paragraph, got %q", e.BreakingNote)
+	}
+	e = parseConventionalCommit("Update readme")
+	if e.Type != "" || e.Subject != "Update readme" || e.Breaking {
+		t.Errorf("unexpected entry for non-conventional commit: %+v", e)
+	}
+	e = parseConventionalCommit("Merge pull request #77 from user/branch")
+	if e.PR != 77 {
+		t.Errorf("expected PR 77, got %+v", e)
+	}
+}
+
+func TestInsertChangelogNotes(t *testing.T) {
+	content := "# Changelog\n\nIntro.\n\n## 1.0.0\n\n- old\n"
+	got := insertChangelogNotes(content, "## 1.1.0\n\n- new\n")
+	want := "# Changelog\n\nIntro.\n\n## 1.1.0\n\n- new\n\n## 1.0.0\n\n- old\n"
+	if got != want {
+		t.Errorf("unexpected content:\n%s", got)
+	}
+	if got := insertChangelogNotes("# Changelog\n", "## 1.0.0\n"); got != "# Changelog\n\n## 1.0.0\n" {
+		t.Errorf("unexpected content for empty changelog: %q", got)
+	}
+}
+
+func TestRenderMarkdownChangelog(t *testing.T) {
+	cl := Changelog{
+		Version: "v1.2.0",
+		Date:    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Entries: []ChangelogEntry{
+			{Hash: "aaaaaaa1", Type: "feat", Scope: "ui", Subject: "add dark mode", PR: 5},
+			{Hash: "bbbbbbb2", Type: "fix", Subject: "handle empty repo", Breaking: true, BreakingNote: "config moved"},
+			{Hash: "ccccccc3", Subject: "Tidy up"},
+		},
+	}
+	out := renderMarkdownChangelog(cl)
+	for _, want := range []string{"## v1.2.0 (2024-05-01)", "### ⚠ BREAKING CHANGES", "- config moved (bbbbbbb)", "### Features", "- **ui:** add dark mode (#5) (aaaaaaa)", "### Bug Fixes", "### Other Changes", "- Tidy up (ccccccc)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+	kac := renderKeepAChangelog(cl)
+	for _, want := range []string{"## [1.2.0] - 2024-05-01", "### Added", "### Fixed", "- **BREAKING:** handle empty repo", "### Changed"} {
+		if !strings.Contains(kac, want) {
+			t.Errorf("expected %q in:\n%s", want, kac)
+		}
+	}
+}
+
+func TestGenerateChangelogSinceLastTag(t *testing.T) {
+	dir := initTestRepo(t)
+	gitRun(t, dir, "tag", "v0.1.0")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "feat: add search")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "fix(ui): align buttons (#9)")
+
+	cl, err := GenerateChangelog(dir, ChangelogOptions{Version: "v0.2.0", Prepend: true, Tag: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cl.From != "v0.1.0" || len(cl.Entries) != 2 {
+		t.Fatalf("unexpected changelog: %+v", cl)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, ChangelogFile))
+	if err != nil || !strings.Contains(string(content), "add search") {
+		t.Fatalf("expected notes in %s, got %q, %v", ChangelogFile, content, err)
+	}
+	if subject := gitRun(t, dir, "log", "-1", "--format=%s", "v0.2.0"); subject != "chore(release): v0.2.0\n" {
+		t.Errorf("expected tag on release commit, got %q", subject)
+	}
+	if _, err := GenerateChangelog(dir, ChangelogOptions{Version: "v0.2.0", Tag: true}); err == nil {
+		t.Error("expected existing tag to be rejected")
+	}
+}
+
+func TestGenerateChangelogJSON(t *testing.T) {
+	dir := initTestRepo(t)
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "feat: add search")
+	cl, err := GenerateChangelog(dir, ChangelogOptions{Format: "json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(cl.Rendered), &decoded); err != nil {
+		t.Fatalf("expected JSON, got %q: %v", cl.Rendered, err)
+	}
+	if _, ok := decoded["Rendered"]; ok {
+		t.Errorf("JSON output includes Rendered: %s", cl.Rendered)
+	}
+	if entries, _ := decoded["Entries"].([]any); len(entries) != 2 {
+		t.Errorf("unexpected entries: %s", cl.Rendered)
+	}
+}
+
+func TestGenerateChangelogPullRequestMerge(t *testing.T) {
+	dir := initTestRepo(t)
+	gitRun(t, dir, "tag", "v1.0.0")
+	gitRun(t, dir, "switch", "-q", "-c", "feature")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "feat: add export")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "fix: export encoding")
+	gitRun(t, dir, "switch", "-q", "main")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "docs: usage")
+	gitRun(t, dir, "merge", "--no-ff", "-m", "Merge pull request #42 from someone/feature", "feature")
+	gitRun(t, dir, "switch", "-q", "-c", "local")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "chore: tidy")
+	gitRun(t, dir, "switch", "-q", "main")
+	gitRun(t, dir, "merge", "--no-ff", "-m", "Merge branch 'local'", "local")
+
+	cl, err := GenerateChangelog(dir, ChangelogOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prs := make(map[string]int)
+	for _, e := range cl.Entries {
+		if strings.HasPrefix(e.Subject, "Merge") {
+			t.Errorf("merge commit listed: %+v", e)
+		}
+		prs[e.Subject] = e.PR
+	}
+	if len(cl.Entries) != 4 {
+		t.Fatalf("expected 4 entries, got %+v", cl.Entries)
+	}
+	if prs["add export"] != 42 || prs["export encoding"] != 42 || prs["usage"] != 0 || prs["tidy"] != 0 {
+		t.Errorf("unexpected pull request numbers: %v", prs)
+	}
+	if !strings.Contains(cl.Rendered, "- add export (#42)") {
+		t.Errorf("expected pull request link in:\n%s", cl.Rendered)
+	}
+}
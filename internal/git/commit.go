@@ -0,0 +1,197 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CommitOptions controls how CommitWithOptions creates a commit.
+type CommitOptions struct {
+	// StageAll stages modified and deleted tracked files first (-a).
+	StageAll bool
+	// Amend replaces the tip of the current branch.
+	Amend bool
+	// NoEdit keeps the existing message when amending. It requires Amend.
+	NoEdit bool
+	// Author overrides the commit author, in "Name <email>" form.
+	Author string
+	// Date overrides the author date, in any format git accepts.
+	Date string
+	// SignOff adds a Signed-off-by trailer.
+	SignOff bool
+	// Sign creates a GPG or SSH signed commit using the configured key.
+	Sign bool
+	// SigningKey selects a specific key and implies Sign.
+	SigningKey string
+	// NoVerify skips the pre-commit and commit-msg hooks.
+	NoVerify bool
+	// AllowEmpty permits a commit that records no changes.
+	AllowEmpty bool
+	// CoAuthors are added as Co-authored-by trailers, in "Name <email>" form.
+	CoAuthors []string
+}
+
+// CommitWithOptions creates a commit in repoPath. The message is passed to
+// git through a temporary file so multi-paragraph messages are kept intact.
+func CommitWithOptions(repoPath, msg string, opts CommitOptions) (string, error) {
+	repo := repoPath
+	if err := validateGitRepo(repo); err != nil {
+		return "", err
+	}
+
+	// Instructions left in from the commit template are not part of the message.
+	msg = stripCommentLines(msg, templateComments(repo))
+	if opts.NoEdit {
+		if !opts.Amend {
+			return "", errors.New("keeping the message (--no-edit) requires amend")
+		}
+		if msg != "" || len(opts.CoAuthors) > 0 {
+			return "", errors.New("cannot change the message when keeping it (--no-edit)")
+		}
+	} else if msg == "" {
+		return "", errors.New("commit message cannot be empty")
+	}
+
+	args := []string{"-C", repo, "commit"}
+	if opts.StageAll {
+		args = append(args, "-a")
+	}
+	if opts.Amend {
+		args = append(args, "--amend")
+	}
+	if author := strings.TrimSpace(opts.Author); author != "" {
+		if !isIdent(author) {
+			return "", errors.New("author must be in the form \"Name <email>\"")
+		}
+		args = append(args, "--author="+author)
+	}
+	if date := strings.TrimSpace(opts.Date); date != "" {
+		args = append(args, "--date="+date)
+	}
+	if opts.SignOff {
+		args = append(args, "--signoff")
+	}
+	if key := strings.TrimSpace(opts.SigningKey); key != "" {
+		args = append(args, "-S"+key)
+	} else if opts.Sign {
+		args = append(args, "-S")
+	}
+	if opts.NoVerify {
+		args = append(args, "--no-verify")
+	}
+	if opts.AllowEmpty {
+		args = append(args, "--allow-empty")
+	}
+
+	if opts.NoEdit {
+		args = append(args, "--no-edit")
+	} else {
+		var trailers []string
+		for _, co := range opts.CoAuthors {
+			co = strings.TrimSpace(co)
+			if co == "" {
+				continue
+			}
+			if !isIdent(co) {
+				return "", fmt.Errorf("co-author %q must be in the form \"Name <email>\"", co)
+			}
+			trailers = append(trailers, "Co-authored-by: "+co)
+		}
+		msg = appendTrailers(msg, trailers)
+
+		// Like commit hooks, repository lint rules are skipped by NoVerify.
+		if !opts.NoVerify {
+			rules, found, err := LoadLintRules(repo)
+			if err != nil {
+				return "", err
+			}
+			if found {
+				lintMsg := msg
+				if opts.SignOff {
+					// git appends the sign-off itself, after linting.
+					lintMsg = appendTrailers(msg, []string{"Signed-off-by: (added by --signoff)"})
+				}
+				if violations := LintCommitMessage(lintMsg, rules); hasLintErrors(violations) {
+					saveCommitMessage(repo, msg)
+					return "", &LintError{Violations: violations}
+				}
+			}
+		}
+
+		msgFile, err := os.CreateTemp("", "gitscope-commit-*.txt")
+		if err != nil {
+			return "", fmt.Errorf("writing commit message failed: %v", err)
+		}
+		defer os.Remove(msgFile.Name())
+		_, err = msgFile.WriteString(msg + "\n")
+		if closeErr := msgFile.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", fmt.Errorf("writing commit message failed: %v", err)
+		}
+		args = append(args, "-F", msgFile.Name())
+	}
+
+	if !opts.StageAll && !opts.Amend && !opts.AllowEmpty {
+		statusCmd := exec.Command("git", "-C", repo, "diff", "--cached", "--quiet")
+		hideWindow(statusCmd)
+		if err := statusCmd.Run(); err == nil {
+			return "", errors.New("no staged changes to commit")
+		}
+	}
+
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+
+	if err != nil {
+		if !opts.NoEdit {
+			saveCommitMessage(repo, msg)
+		}
+		return string(out), fmt.Errorf("commit failed: %v\n%s", err, string(out))
+	}
+
+	if len(out) == 0 {
+		return "Commit completed successfully.", nil
+	}
+
+	return string(out), nil
+}
+
+// isIdent reports whether s looks like a "Name <email>" identity.
+func isIdent(s string) bool {
+	open := strings.Index(s, "<")
+	return open > 0 && strings.HasSuffix(s, ">") && strings.TrimSpace(s[:open]) != "" && len(s)-open > 2
+}
+
+// appendTrailers adds trailer lines to msg, joining an existing trailer
+// block when the last paragraph already is one.
+func appendTrailers(msg string, trailers []string) string {
+	if len(trailers) == 0 {
+		return msg
+	}
+	paragraphs := strings.Split(msg, "\n\n")
+	last := paragraphs[len(paragraphs)-1]
+	if len(paragraphs) > 1 && isTrailerBlock(last) {
+		return msg + "\n" + strings.Join(trailers, "\n")
+	}
+	return msg + "\n\n" + strings.Join(trailers, "\n")
+}
+
+// isTrailerBlock reports whether every line of block is a "Key: value" trailer.
+func isTrailerBlock(block string) bool {
+	for _, line := range strings.Split(strings.TrimSpace(block), "\n") {
+		key, _, ok := strings.Cut(line, ": ")
+		if key == "BREAKING CHANGE" {
+			continue
+		}
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return false
+		}
+	}
+	return true
+}
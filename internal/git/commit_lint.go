@@ -0,0 +1,234 @@
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// LintConfigFile is the per-repository commit message linter configuration,
+// relative to the repository root. When present, CommitWithOptions refuses
+// messages with error-level violations unless NoVerify is set.
+const LintConfigFile = ".gitscope-commitlint.json"
+
+// LintRules configures LintCommitMessage.
+type LintRules struct {
+	// Types lists allowed Conventional Commit types. Empty allows any type.
+	Types []string `json:"types"`
+	// Scopes lists allowed scopes. Empty allows any scope.
+	Scopes       []string `json:"scopes"`
+	RequireScope bool     `json:"requireScope"`
+	// MaxHeaderLength limits the first line. Zero disables the check.
+	MaxHeaderLength int `json:"maxHeaderLength"`
+	// BodyWrapWidth limits body line length. Zero disables the check.
+	BodyWrapWidth  int  `json:"bodyWrapWidth"`
+	ImperativeMood bool `json:"imperativeMood"`
+	// IssuePattern is a regular expression that must match somewhere in the
+	// message, e.g. "#[0-9]+" or "[A-Z]+-[0-9]+". Empty disables the check.
+	IssuePattern string `json:"issuePattern"`
+	// RequiredTrailers lists trailer keys that must be present, e.g. "Signed-off-by".
+	RequiredTrailers []string `json:"requiredTrailers"`
+}
+
+// LintViolation is a single problem found by LintCommitMessage.
+type LintViolation struct {
+	Rule     string
+	Severity string // "error" or "warning"
+	Line     int
+	Message  string
+}
+
+// LintError is returned by CommitWithOptions when the message fails linting.
+type LintError struct {
+	Violations []LintViolation
+}
+
+func (e *LintError) Error() string {
+	var b strings.Builder
+	b.WriteString("commit message rejected by linter:")
+	for _, v := range e.Violations {
+		if v.Severity == "error" {
+			fmt.Fprintf(&b, "\n  line %d: %s", v.Line, v.Message)
+		}
+	}
+	return b.String()
+}
+
+// DefaultLintRules returns the Conventional Commits defaults.
+func DefaultLintRules() LintRules {
+	return LintRules{
+		Types:           []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"},
+		MaxHeaderLength: 72,
+		BodyWrapWidth:   100,
+		ImperativeMood:  true,
+	}
+}
+
+// LoadLintRules reads LintConfigFile from repoPath. Fields missing from the
+// file keep their defaults. found reports whether the file exists.
+func LoadLintRules(repoPath string) (rules LintRules, found bool, err error) {
+	rules = DefaultLintRules()
+	data, err := os.ReadFile(filepath.Join(repoPath, LintConfigFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return rules, false, nil
+		}
+		return rules, false, err
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, true, fmt.Errorf("parsing %s failed: %v", LintConfigFile, err)
+	}
+	if rules.IssuePattern != "" {
+		if _, err := regexp.Compile(rules.IssuePattern); err != nil {
+			return rules, true, fmt.Errorf("invalid issuePattern in %s: %v", LintConfigFile, err)
+		}
+	}
+	return rules, true, nil
+}
+
+var conventionalHeader = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()]*)\))?(!)?: (.*)$`)
+
+// nonImperativeSuffixes flag subjects like "added", "adding" or "adds".
+var nonImperativeSuffixes = []string{"ed", "ing", "es", "s"}
+
+// imperativeExceptions are verbs that end in a flagged suffix but are imperative.
+var imperativeExceptions = []string{"embed", "exceed", "feed", "focus", "need", "proceed", "seed", "shed", "speed", "succeed", "bring", "string", "alias", "canvas"}
+
+// LintCommitMessage checks msg against rules and returns every violation found.
+func LintCommitMessage(msg string, rules LintRules) []LintViolation {
+	var violations []LintViolation
+	add := func(rule, severity string, line int, format string, args ...any) {
+		violations = append(violations, LintViolation{Rule: rule, Severity: severity, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	msg = strings.TrimRight(strings.ReplaceAll(msg, "\r\n", "\n"), "\n")
+	lines := strings.Split(msg, "\n")
+	header := lines[0]
+	if strings.TrimSpace(header) == "" {
+		add("header-empty", "error", 1, "subject line is empty")
+		return violations
+	}
+
+	if rules.MaxHeaderLength > 0 && len([]rune(header)) > rules.MaxHeaderLength {
+		add("header-max-length", "error", 1, "subject line is %d characters, limit is %d", len([]rune(header)), rules.MaxHeaderLength)
+	}
+
+	m := conventionalHeader.FindStringSubmatch(header)
+	if m == nil {
+		add("header-format", "error", 1, "subject must look like \"type(scope): description\"")
+	} else {
+		typ, scope, subject := m[1], m[2], m[4]
+		if len(rules.Types) > 0 && !slices.Contains(rules.Types, typ) {
+			add("type-enum", "error", 1, "type %q is not one of %s", typ, strings.Join(rules.Types, ", "))
+		}
+		if scope == "" && rules.RequireScope {
+			add("scope-empty", "error", 1, "a scope is required")
+		}
+		if scope != "" && len(rules.Scopes) > 0 && !slices.Contains(rules.Scopes, scope) {
+			add("scope-enum", "error", 1, "scope %q is not one of %s", scope, strings.Join(rules.Scopes, ", "))
+		}
+		if strings.TrimSpace(subject) == "" {
+			add("subject-empty", "error", 1, "description is empty")
+		} else {
+			if strings.HasSuffix(subject, ".") {
+				add("subject-full-stop", "warning", 1, "description should not end with a period")
+			}
+			if rules.ImperativeMood && !isImperative(subject) {
+				add("subject-imperative", "warning", 1, "use the imperative mood, e.g. \"add\" rather than \"added\" or \"adds\"")
+			}
+		}
+	}
+
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		add("body-leading-blank", "error", 2, "the subject must be followed by a blank line")
+	}
+
+	paragraphs := strings.Split(msg, "\n\n")
+	var trailerBlock string
+	if len(paragraphs) > 1 && isTrailerBlock(paragraphs[len(paragraphs)-1]) {
+		trailerBlock = paragraphs[len(paragraphs)-1]
+	}
+
+	if rules.BodyWrapWidth > 0 {
+		for i, line := range lines[1:] {
+			// Long unbreakable lines such as URLs cannot be wrapped.
+			if len([]rune(line)) > rules.BodyWrapWidth && strings.Contains(strings.TrimSpace(line), " ") {
+				add("body-max-line-length", "warning", i+2, "line is %d characters, wrap at %d", len([]rune(line)), rules.BodyWrapWidth)
+			}
+		}
+	}
+
+	if rules.IssuePattern != "" {
+		if re, err := regexp.Compile(rules.IssuePattern); err == nil && !re.MatchString(msg) {
+			add("references-empty", "error", 1, "message must reference an issue matching %s", rules.IssuePattern)
+		}
+	}
+
+	trailers := make(map[string]bool)
+	if trailerBlock != "" {
+		start := len(lines) - len(strings.Split(trailerBlock, "\n")) + 1
+		for i, line := range strings.Split(trailerBlock, "\n") {
+			key, value, _ := strings.Cut(line, ": ")
+			if strings.TrimSpace(value) == "" {
+				add("trailer-value", "error", start+i, "trailer %q has no value", key)
+			}
+			trailers[strings.ToLower(key)] = true
+		}
+	}
+	for _, key := range rules.RequiredTrailers {
+		if !trailers[strings.ToLower(key)] {
+			add("trailer-required", "error", len(lines), "missing required trailer %q", key)
+		}
+	}
+	return violations
+}
+
+// isImperative is a heuristic check that the first word of subject is an
+// imperative verb.
+func isImperative(subject string) bool {
+	fields := strings.Fields(subject)
+	if len(fields) == 0 {
+		return true
+	}
+	word := strings.ToLower(strings.Trim(fields[0], ".,:;"))
+	if slices.Contains(imperativeExceptions, word) {
+		return true
+	}
+	for _, suffix := range nonImperativeSuffixes {
+		if len(word) > len(suffix)+2 && strings.HasSuffix(word, suffix) && !strings.HasSuffix(word, "ss") {
+			return false
+		}
+	}
+	return true
+}
+
+// hasLintErrors reports whether violations contains any error-level entries.
+func hasLintErrors(violations []LintViolation) bool {
+	for _, v := range violations {
+		if v.Severity == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// LintRepoCommitMessage lints msg with the rules configured in repoPath, or
+// the defaults when the repository has no LintConfigFile.
+func LintRepoCommitMessage(repoPath, msg string) ([]LintViolation, error) {
+	if err := validateRepoPath(repoPath); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(msg) == "" {
+		return nil, errors.New("commit message cannot be empty")
+	}
+	rules, _, err := LoadLintRules(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return LintCommitMessage(msg, rules), nil
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func lintRules(violations []LintViolation) map[string]string {
+	rules := make(map[string]string)
+	for _, v := range violations {
+		rules[v.Rule] = v.Severity
+	}
+	return rules
+}
+
+func TestLintCommitMessageValid(t *testing.T) {
+	msg := "feat(ui): add stash browser\n\nShow every stash with its branch and date.\n\nRefs: #42\nSigned-off-by: A <a@x>"
+	rules := DefaultLintRules()
+	rules.IssuePattern = "#[0-9]+"
+	rules.RequiredTrailers = []string{"Signed-off-by"}
+	if v := LintCommitMessage(msg, rules); len(v) != 0 {
+		t.Errorf("expected no violations, got %+v", v)
+	}
+}
+
+func TestLintCommitMessageViolations(t *testing.T) {
+	rules := DefaultLintRules()
+	rules.Scopes = []string{"ui", "git"}
+	rules.IssuePattern = "#[0-9]+"
+	rules.RequiredTrailers = []string{"Signed-off-by"}
+
+	got := lintRules(LintCommitMessage("feature(db): Added things.\nno blank line", rules))
+	want := map[string]string{
+		"type-enum":          "error",
+		"scope-enum":         "error",
+		"subject-full-stop":  "warning",
+		"subject-imperative": "warning",
+		"body-leading-blank": "error",
+		"references-empty":   "error",
+		"trailer-required":   "error",
+	}
+	for rule, severity := range want {
+		if got[rule] != severity {
+			t.Errorf("expected %s violation %q, got %+v", severity, rule, got)
+		}
+	}
+
+	got = lintRules(LintCommitMessage("just a sentence", DefaultLintRules()))
+	if got["header-format"] != "error" {
+		t.Errorf("expected header-format error, got %+v", got)
+	}
+}
+
+func TestIsImperative(t *testing.T) {
+	for _, s := range []string{"add x", "fix bug", "embed fonts", "update deps", "bypass cache"} {
+		if !isImperative(s) {
+			t.Errorf("expected %q to be imperative", s)
+		}
+	}
+	for _, s := range []string{"added x", "fixes bug", "updating deps"} {
+		if isImperative(s) {
+			t.Errorf("expected %q to be flagged", s)
+		}
+	}
+}
+
+func TestCommitRejectedByRepoLintRules(t *testing.T) {
+	dir := initTestRepo(t)
+	config := `{"types": ["feat", "fix"], "issuePattern": "#[0-9]+"}`
+	if err := os.WriteFile(filepath.Join(dir, LintConfigFile), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rules, found, err := LoadLintRules(dir)
+	if err != nil || !found || len(rules.Types) != 2 || rules.MaxHeaderLength != 72 {
+		t.Fatalf("unexpected rules: %+v, %v, %v", rules, found, err)
+	}
+
+	_, err = CommitWithOptions(dir, "chore: tidy", CommitOptions{AllowEmpty: true})
+	var lintErr *LintError
+	if !errors.As(err, &lintErr) || len(lintErr.Violations) == 0 {
+		t.Fatalf("expected lint error, got %v", err)
+	}
+	if _, err := CommitWithOptions(dir, "chore: tidy", CommitOptions{AllowEmpty: true, NoVerify: true}); err != nil {
+		t.Fatalf("expected NoVerify to skip linting: %v", err)
+	}
+	if _, err := CommitWithOptions(dir, "fix: tidy up (#7)", CommitOptions{AllowEmpty: true}); err != nil {
+		t.Fatalf("expected valid message to commit: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// CommitTemplate returns the contents of the file configured as
+// commit.template, or "" when none is configured. Relative paths are
+// resolved against the repository root.
+func CommitTemplate(repoPath string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	cmd := exec.Command("git", "-C", repoPath, "config", "--path", "--get", "commit.template")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		// Exit status 1 means the key is not set.
+		return "", nil
+	}
+	p := strings.TrimSpace(string(out))
+	if p == "" {
+		return "", nil
+	}
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(repoPath, p)
+	}
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return "", fmt.Errorf("reading commit template failed: %v", err)
+	}
+	return string(content), nil
+}
+
+// RecoverCommitMessage returns the message left in .git/COMMIT_EDITMSG by
+// the last commit attempt, without the commit template's comment lines. It
+// lets the message of a commit that failed (for example in a hook) be
+// restored.
+func RecoverCommitMessage(repoPath string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(gitPath(repoPath, "COMMIT_EDITMSG"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return stripCommentLines(string(content), templateComments(repoPath)), nil
+}
+
+// templateComments returns the comment lines of the commit template, which
+// guide the author and are not meant to be committed. Other lines starting
+// with the comment character, such as "#123" or markdown headings, are
+// part of the message since commits are made with -F.
+func templateComments(repoPath string) map[string]bool {
+	comments := make(map[string]bool)
+	tpl, err := CommitTemplate(repoPath)
+	if err != nil || tpl == "" {
+		return comments
+	}
+	char := "#"
+	cmd := exec.Command("git", "-C", repoPath, "config", "--get", "core.commentChar")
+	hideWindow(cmd)
+	if out, err := cmd.Output(); err == nil {
+		if c := strings.TrimSpace(string(out)); c != "" && c != "auto" {
+			char = c
+		}
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(tpl, "\r\n", "\n"), "\n") {
+		if line = strings.TrimRight(line, " \t"); strings.HasPrefix(line, char) {
+			comments[line] = true
+		}
+	}
+	return comments
+}
+
+// stripCommentLines drops the given comment lines from msg, and the
+// verbose diff git appends after the scissors line.
+func stripCommentLines(msg string, comments map[string]bool) string {
+	var kept []string
+	for _, line := range strings.Split(strings.ReplaceAll(msg, "\r\n", "\n"), "\n") {
+		if strings.HasSuffix(line, " ------------------------ >8 ------------------------") {
+			break
+		}
+		if comments[strings.TrimRight(line, " \t")] {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// saveCommitMessage writes msg to .git/COMMIT_EDITMSG so RecoverCommitMessage
+// can offer it again. Git does not update the file when a commit is stopped
+// before the message is prepared, e.g. by the pre-commit hook or the linter.
+func saveCommitMessage(repoPath, msg string) {
+	_ = os.WriteFile(gitPath(repoPath, "COMMIT_EDITMSG"), []byte(msg+"\n"), 0644)
+}
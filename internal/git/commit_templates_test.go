@@ -0,0 +1,54 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStripCommentLines(t *testing.T) {
+	in := "fix: keep this\n\n## Notes\n#123 is fixed.\n# Explain why  \n# ------------------------ >8 ------------------------\ndiff --git a/x b/x\n"
+	if got := stripCommentLines(in, map[string]bool{"# Explain why": true}); got != "fix: keep this\n\n## Notes\n#123 is fixed." {
+		t.Errorf("unexpected message: %q", got)
+	}
+}
+
+func TestCommitTemplateAndRecovery(t *testing.T) {
+	dir := initTestRepo(t)
+	if tpl, err := CommitTemplate(dir); err != nil || tpl != "" {
+		t.Fatalf("expected no template, got %q, %v", tpl, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitmessage"), []byte("type(scope): \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "config", "commit.template", ".gitmessage")
+	if tpl, err := CommitTemplate(dir); err != nil || tpl != "type(scope): \n" {
+		t.Fatalf("unexpected template %q, %v", tpl, err)
+	}
+
+	hook := filepath.Join(dir, ".git", "hooks", "pre-commit")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CommitWithOptions(dir, "feat: lost message", CommitOptions{AllowEmpty: true}); err == nil {
+		t.Fatal("expected pre-commit hook to reject the commit")
+	}
+	if msg, err := RecoverCommitMessage(dir); err != nil || msg != "feat: lost message" {
+		t.Errorf("unexpected recovered message %q, %v", msg, err)
+	}
+}
+
+func TestCommitKeepsHashLines(t *testing.T) {
+	dir := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, ".gitmessage"), []byte("type(scope): \n\n# Explain why.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "config", "commit.template", ".gitmessage")
+	msg := "docs: notes\n\n# Explain why.\n#123 is fixed.\n## Heading"
+	if _, err := CommitWithOptions(dir, msg, CommitOptions{AllowEmpty: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := gitRun(t, dir, "log", "-1", "--format=%B"); got != "docs: notes\n\n#123 is fixed.\n## Heading\n\n" {
+		t.Errorf("unexpected committed message %q", got)
+	}
+}
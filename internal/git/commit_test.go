@@ -0,0 +1,75 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAppendTrailers(t *testing.T) {
+	got := appendTrailers("feat: add x\n\nLonger body.", []string{"Co-authored-by: A <a@x>"})
+	if got != "feat: add x\n\nLonger body.\n\nCo-authored-by: A <a@x>" {
+		t.Errorf("unexpected message: %q", got)
+	}
+	got = appendTrailers("fix: y\n\nRefs: #12", []string{"Co-authored-by: B <b@x>"})
+	if got != "fix: y\n\nRefs: #12\nCo-authored-by: B <b@x>" {
+		t.Errorf("expected trailer to join existing block, got %q", got)
+	}
+	if got := appendTrailers("chore: z", nil); got != "chore: z" {
+		t.Errorf("expected message unchanged, got %q", got)
+	}
+}
+
+func TestIsIdent(t *testing.T) {
+	for _, s := range []string{"Jane Doe <jane@example.com>", "J <j@x>"} {
+		if !isIdent(s) {
+			t.Errorf("expected %q to be a valid identity", s)
+		}
+	}
+	for _, s := range []string{"jane@example.com", "<jane@example.com>", "Jane <>", "Jane"} {
+		if isIdent(s) {
+			t.Errorf("expected %q to be rejected", s)
+		}
+	}
+}
+
+func TestCommitWithOptions(t *testing.T) {
+	dir := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("updated\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	msg := "feat: update readme\n\nFirst paragraph.\n\nSecond paragraph."
+	opts := CommitOptions{
+		StageAll:  true,
+		Author:    "Other Person <other@example.com>",
+		Date:      "2020-01-02T03:04:05Z",
+		SignOff:   true,
+		CoAuthors: []string{"Pair Programmer <pair@example.com>"},
+	}
+	if _, err := CommitWithOptions(dir, msg, opts); err != nil {
+		t.Fatal(err)
+	}
+	body := gitRun(t, dir, "log", "-1", "--format=%B")
+	for _, want := range []string{"Second paragraph.", "Co-authored-by: Pair Programmer <pair@example.com>", "Signed-off-by: Test <test@example.com>"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected message to contain %q, got %q", want, body)
+		}
+	}
+	if author := gitRun(t, dir, "log", "-1", "--format=%an <%ae> %at"); author != "Other Person <other@example.com> 1577934245\n" {
+		t.Errorf("unexpected author line: %q", author)
+	}
+
+	if _, err := CommitWithOptions(dir, "", CommitOptions{Amend: true, NoEdit: true}); err != nil {
+		t.Fatalf("amend --no-edit failed: %v", err)
+	}
+	if _, err := CommitWithOptions(dir, "", CommitOptions{NoEdit: true}); err == nil {
+		t.Error("expected --no-edit without amend to be rejected")
+	}
+	if _, err := CommitWithOptions(dir, "chore: nothing", CommitOptions{}); err == nil {
+		t.Error("expected commit without staged changes to be rejected")
+	}
+	if _, err := CommitWithOptions(dir, "chore: empty", CommitOptions{AllowEmpty: true}); err != nil {
+		t.Fatalf("allow-empty commit failed: %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommitSummary is the one-line view of a commit used by comparisons,
+// file history and search results.
+type CommitSummary struct {
+	Hash        string
+	ShortHash   string
+	Author      string
+	AuthorEmail string
+	Date        time.Time
+	Subject     string
+	// Equivalent is set when a commit with the same patch exists on the
+	// other side of a comparison, i.e. it was already cherry-picked.
+	Equivalent bool
+}
+
+// FileStat is the number of lines changed in one file.
+type FileStat struct {
+	Path    string
+	OldPath string
+	Added   int
+	Deleted int
+	Binary  bool
+}
+
+// RefComparison describes how two refs have diverged.
+type RefComparison struct {
+	A         string
+	B         string
+	MergeBase string
+	// OnlyA and OnlyB list the commits reachable from one side only,
+	// newest first.
+	OnlyA []CommitSummary
+	OnlyB []CommitSummary
+	// Files is the diffstat between the tips of A and B.
+	Files   []FileStat
+	Added   int
+	Deleted int
+}
+
+// commitSummaryFormat is the `git log` format parsed by parseCommitSummaries.
+const commitSummaryFormat = "--format=%m%x00%H%x00%h%x00%an%x00%ae%x00%at%x00%s%x1e"
+
+// CompareRefs compares revisions a and b: their merge base, the commits
+// found on only one side (`a...b --left-right`), the combined diffstat and
+// which of those commits have a patch-equivalent commit on the other side.
+func CompareRefs(repoPath, a, b string) (RefComparison, error) {
+	res := RefComparison{A: strings.TrimSpace(a), B: strings.TrimSpace(b)}
+	if err := validateGitRepo(repoPath); err != nil {
+		return res, err
+	}
+	for _, ref := range []string{res.A, res.B} {
+		if ref == "" || strings.HasPrefix(ref, "-") {
+			return res, fmt.Errorf("invalid revision %q", ref)
+		}
+		if err := verifyRevision(repoPath, ref); err != nil {
+			return res, err
+		}
+	}
+
+	base := exec.Command("git", "-C", repoPath, "merge-base", res.A, res.B)
+	hideWindow(base)
+	if out, err := base.Output(); err == nil {
+		res.MergeBase = strings.TrimSpace(string(out))
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "log", "--left-right", commitSummaryFormat, res.A+"..."+res.B, "--")
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return res, fmt.Errorf("log failed: %v\n%s", err, string(out))
+	}
+	for _, c := range parseCommitSummaries(string(out)) {
+		if c.mark == "<" {
+			res.OnlyA = append(res.OnlyA, c.CommitSummary)
+		} else {
+			res.OnlyB = append(res.OnlyB, c.CommitSummary)
+		}
+	}
+	markEquivalent(res.OnlyA, cherryEquivalents(repoPath, res.B, res.A))
+	markEquivalent(res.OnlyB, cherryEquivalents(repoPath, res.A, res.B))
+
+	stat := exec.Command("git", "-C", repoPath, "-c", "core.quotePath=false", "diff", "--numstat", "-z", "-M", res.A, res.B, "--")
+	hideWindow(stat)
+	out, err = stat.Output()
+	if err != nil {
+		return res, fmt.Errorf("diff failed: %v", err)
+	}
+	res.Files = parseNumstat(string(out))
+	for _, f := range res.Files {
+		res.Added += f.Added
+		res.Deleted += f.Deleted
+	}
+	return res, nil
+}
+
+// verifyRevision checks that rev names a commit.
+func verifyRevision(repoPath, rev string) error {
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	hideWindow(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("unknown revision %q", rev)
+	}
+	return nil
+}
+
+type markedCommit struct {
+	CommitSummary
+	mark string
+}
+
+// parseCommitSummaries parses `git log` output in commitSummaryFormat.
+func parseCommitSummaries(out string) []markedCommit {
+	var commits []markedCommit
+	for _, rec := range strings.Split(out, "\x1e") {
+		rec = strings.TrimLeft(rec, "\n")
+		if rec == "" {
+			continue
+		}
+		f := strings.SplitN(rec, "\x00", 7)
+		if len(f) < 7 {
+			continue
+		}
+		commits = append(commits, markedCommit{CommitSummary: commitSummaryFields(f[1:]), mark: f[0]})
+	}
+	return commits
+}
+
+// commitSummaryFields builds a CommitSummary from the %H, %h, %an, %ae,
+// %at and %s fields of a log record.
+func commitSummaryFields(f []string) CommitSummary {
+	c := CommitSummary{Hash: f[0], ShortHash: f[1], Author: f[2], AuthorEmail: f[3], Subject: f[5]}
+	if secs, err := strconv.ParseInt(f[4], 10, 64); err == nil {
+		c.Date = time.Unix(secs, 0)
+	}
+	return c
+}
+
+// cherryEquivalents runs `git cherry upstream head` and returns the commits
+// of head whose change already exists in upstream.
+func cherryEquivalents(repoPath, upstream, head string) map[string]bool {
+	cmd := exec.Command("git", "-C", repoPath, "cherry", upstream, head)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	equivalent := make(map[string]bool)
+	for _, line := range strings.Split(string(out), "\n") {
+		if hash, ok := strings.CutPrefix(line, "- "); ok {
+			equivalent[strings.TrimSpace(hash)] = true
+		}
+	}
+	return equivalent
+}
+
+func markEquivalent(commits []CommitSummary, equivalent map[string]bool) {
+	for i := range commits {
+		commits[i].Equivalent = equivalent[commits[i].Hash]
+	}
+}
+
+// parseNumstat parses `git diff --numstat -z` output. Renamed files are
+// written as "added\tdeleted\t\0old\0new\0".
+func parseNumstat(out string) []FileStat {
+	var files []FileStat
+	fields := strings.Split(out, "\x00")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		f := FileStat{Path: parts[2]}
+		if parts[0] == "-" && parts[1] == "-" {
+			f.Binary = true
+		} else {
+			f.Added, _ = strconv.Atoi(parts[0])
+			f.Deleted, _ = strconv.Atoi(parts[1])
+		}
+		if f.Path == "" && i+2 < len(fields) {
+			f.OldPath, f.Path = fields[i+1], fields[i+2]
+			i += 2
+		}
+		files = append(files, f)
+	}
+	return files
+}
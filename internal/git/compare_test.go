@@ -0,0 +1,73 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareRefs(t *testing.T) {
+	dir := initTestRepo(t)
+	base := strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD"))
+
+	gitRun(t, dir, "checkout", "-q", "-b", "feature")
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "add", "a.txt")
+	gitRun(t, dir, "commit", "-q", "-m", "add a")
+	picked := strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD"))
+	if err := os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\nb\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "add", "b.txt")
+	gitRun(t, dir, "commit", "-q", "-m", "add b")
+
+	gitRun(t, dir, "checkout", "-q", "main")
+	gitRun(t, dir, "cherry-pick", "-x", picked)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\nmain\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "commit", "-q", "-am", "update readme")
+
+	res, err := CompareRefs(dir, "main", "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.MergeBase != base {
+		t.Errorf("merge base = %q, want %q", res.MergeBase, base)
+	}
+	if len(res.OnlyA) != 2 || len(res.OnlyB) != 2 {
+		t.Fatalf("unexpected sides: %+v / %+v", res.OnlyA, res.OnlyB)
+	}
+	if res.OnlyA[0].Subject != "update readme" || res.OnlyA[0].Equivalent || !res.OnlyA[1].Equivalent {
+		t.Errorf("unexpected main-only commits: %+v", res.OnlyA)
+	}
+	if res.OnlyB[0].Subject != "add b" || res.OnlyB[0].Equivalent || !res.OnlyB[1].Equivalent || res.OnlyB[1].Hash != picked {
+		t.Errorf("unexpected feature-only commits: %+v", res.OnlyB)
+	}
+	if len(res.Files) != 2 || res.Added != 2 || res.Deleted != 1 {
+		t.Errorf("unexpected diffstat: %+v (+%d -%d)", res.Files, res.Added, res.Deleted)
+	}
+
+	if _, err := CompareRefs(dir, "main", "nope"); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	files := parseNumstat("1\t2\tplain.txt\x00-\t-\tlogo.png\x003\t0\t\x00old.go\x00new.go\x00")
+	if len(files) != 3 {
+		t.Fatalf("unexpected files: %+v", files)
+	}
+	if files[0].Path != "plain.txt" || files[0].Added != 1 || files[0].Deleted != 2 {
+		t.Errorf("unexpected first file: %+v", files[0])
+	}
+	if !files[1].Binary {
+		t.Errorf("expected binary file: %+v", files[1])
+	}
+	if files[2].OldPath != "old.go" || files[2].Path != "new.go" || files[2].Added != 3 {
+		t.Errorf("unexpected rename: %+v", files[2])
+	}
+}
@@ -0,0 +1,495 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gitscope/internal/highlight"
+)
+
+// DiffOptions controls DiffRefs.
+type DiffOptions struct {
+	// IgnoreWhitespace is "" (none), "all" (-w), "change" (-b) or "eol"
+	// (--ignore-space-at-eol).
+	IgnoreWhitespace string
+	IgnoreBlankLines bool
+	// ContextLines sets the number of context lines. Zero uses git's default.
+	ContextLines int
+	// DetectRenames enables rename detection, with RenameThreshold as the
+	// similarity percentage (zero uses git's default of 50).
+	DetectRenames   bool
+	RenameThreshold int
+	// DetectCopies enables copy detection, with CopyThreshold as the
+	// similarity percentage (zero uses git's default of 50).
+	DetectCopies  bool
+	CopyThreshold int
+	// WordDiff fills DiffLine.Segments for paired removed/added lines.
+	WordDiff bool
+	// Highlight fills DiffLine.Spans with syntax highlighting for files
+	// whose language is known and whose hunks are not too large.
+	Highlight bool
+}
+
+// DiffFile is one file in a structured diff.
+type DiffFile struct {
+	OldPath string
+	NewPath string
+	// Status is "added", "deleted", "modified", "renamed" or "copied".
+	Status     string
+	Similarity int
+	Binary     bool
+	Hunks      []DiffHunk
+	// OldLFS and NewLFS are set when the file is an LFS pointer on that
+	// side. Hunks is emptied when every side is one, since it would only
+	// show pointer text.
+	OldLFS *LFSObject
+	NewLFS *LFSObject
+}
+
+// DiffHunk is one @@ section of a file diff.
+type DiffHunk struct {
+	Header   string
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Lines    []DiffLine
+	// Rows aligns Lines for a side-by-side view.
+	Rows []SideBySideRow
+}
+
+// DiffLine is a single line of a hunk.
+type DiffLine struct {
+	// Kind is "context", "add" or "delete".
+	Kind      string
+	Content   string
+	OldNumber int
+	NewNumber int
+	// NoNewline is set when the line is not terminated by a newline.
+	NoNewline bool
+	Segments  []WordSegment
+	Spans     []highlight.Span
+}
+
+// WordSegment is part of a line in a word-level diff.
+type WordSegment struct {
+	Text    string
+	Changed bool
+}
+
+// SideBySideRow holds the left (old) and right (new) line shown on one row.
+// Either side is nil where the other side has no counterpart.
+type SideBySideRow struct {
+	Left  *DiffLine
+	Right *DiffLine
+}
+
+// DiffRefs returns a structured diff between revisions a and b, which may
+// be commits, branches, tags or stashes. An empty b compares a with the
+// working tree. paths optionally limits the diff.
+func DiffRefs(repoPath, a, b string, paths []string, opts DiffOptions) ([]DiffFile, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if a == "" {
+		return nil, errors.New("a revision to compare is required")
+	}
+	if strings.HasPrefix(a, "-") || strings.HasPrefix(b, "-") {
+		return nil, errors.New("invalid revision")
+	}
+	args, err := diffArgs(repoPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, a)
+	if b != "" {
+		args = append(args, b)
+	}
+	args = append(args, "--")
+	args = append(args, paths...)
+
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("diff failed: %v", err)
+	}
+	files := parseUnifiedDiff(string(out))
+	for i := range files {
+		detectLFSPointers(repoPath, &files[i])
+		for j := range files[i].Hunks {
+			h := &files[i].Hunks[j]
+			h.Rows = alignHunk(h.Lines, opts.WordDiff)
+		}
+		if opts.Highlight {
+			highlightDiffFile(&files[i])
+		}
+	}
+	return files, nil
+}
+
+// highlightDiffFile highlights the old and new sides of each hunk
+// separately, so block comments and multi-line strings carry over between
+// lines of the same side. Files too large to highlight keep nil Spans.
+func highlightDiffFile(f *DiffFile) {
+	name := f.NewPath
+	if name == "" {
+		name = f.OldPath
+	}
+	var size int
+	for _, h := range f.Hunks {
+		for _, l := range h.Lines {
+			size += len(l.Content) + 1
+			if len(l.Content) > highlight.MaxLineLength {
+				return
+			}
+		}
+	}
+	if f.Binary || size > highlight.MaxBytes {
+		return
+	}
+	var firstLine string
+	if len(f.Hunks) > 0 && len(f.Hunks[0].Lines) > 0 && f.Hunks[0].NewStart <= 1 {
+		firstLine = f.Hunks[0].Lines[0].Content
+	}
+	lang := highlight.Detect(name, firstLine)
+	if lang == "" {
+		return
+	}
+	for i := range f.Hunks {
+		lines := f.Hunks[i].Lines
+		oldSide, newSide := highlight.New(lang), highlight.New(lang)
+		for j := range lines {
+			l := &lines[j]
+			switch l.Kind {
+			case "delete":
+				l.Spans = oldSide.Line(l.Content)
+			case "add":
+				l.Spans = newSide.Line(l.Content)
+			default:
+				oldSide.Line(l.Content)
+				l.Spans = newSide.Line(l.Content)
+			}
+		}
+	}
+}
+
+// detectLFSPointers replaces the hunks of f with the LFS objects on each
+// side when its diff covers whole LFS pointer files.
+func detectLFSPointers(repoPath string, f *DiffFile) {
+	if len(f.Hunks) != 1 || len(f.Hunks[0].Lines) == 0 || f.Hunks[0].Lines[0].Content != lfsPointerVersion {
+		return
+	}
+	var oldText, newText strings.Builder
+	for _, l := range f.Hunks[0].Lines {
+		if l.Kind != "add" {
+			oldText.WriteString(l.Content + "\n")
+		}
+		if l.Kind != "delete" {
+			newText.WriteString(l.Content + "\n")
+		}
+	}
+	f.OldLFS = lfsPointerObject(repoPath, f.OldPath, []byte(oldText.String()))
+	f.NewLFS = lfsPointerObject(repoPath, f.NewPath, []byte(newText.String()))
+	if (f.OldLFS != nil || f.OldPath == "") && (f.NewLFS != nil || f.NewPath == "") {
+		f.Hunks = nil
+	}
+}
+
+// diffArgs builds the common `git diff` arguments for opts.
+func diffArgs(repoPath string, opts DiffOptions) ([]string, error) {
+	// The parser expects a/ and b/ whatever diff.noprefix or
+	// diff.mnemonicPrefix say.
+	args := []string{"-C", repoPath, "-c", "core.quotePath=false", "diff", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/"}
+	switch opts.IgnoreWhitespace {
+	case "":
+	case "all":
+		args = append(args, "-w")
+	case "change":
+		args = append(args, "-b")
+	case "eol":
+		args = append(args, "--ignore-space-at-eol")
+	default:
+		return nil, fmt.Errorf("unknown whitespace mode: %s", opts.IgnoreWhitespace)
+	}
+	if opts.IgnoreBlankLines {
+		args = append(args, "--ignore-blank-lines")
+	}
+	if opts.ContextLines < 0 {
+		return nil, errors.New("context lines cannot be negative")
+	}
+	if opts.ContextLines > 0 {
+		args = append(args, "-U"+strconv.Itoa(opts.ContextLines))
+	}
+	if opts.DetectRenames || opts.DetectCopies {
+		args = append(args, thresholdFlag("-M", opts.RenameThreshold))
+	} else {
+		args = append(args, "--no-renames")
+	}
+	if opts.DetectCopies {
+		args = append(args, thresholdFlag("-C", opts.CopyThreshold))
+	}
+	return args, nil
+}
+
+func thresholdFlag(flag string, percent int) string {
+	if percent <= 0 || percent > 100 {
+		return flag
+	}
+	return flag + strconv.Itoa(percent) + "%"
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// parseUnifiedDiff converts `git diff` output into files and hunks.
+func parseUnifiedDiff(out string) []DiffFile {
+	var files []DiffFile
+	var file *DiffFile
+	var hunk *DiffHunk
+	oldLine, newLine := 0, 0
+
+	flushHunk := func() {
+		if file != nil && hunk != nil {
+			file.Hunks = append(file.Hunks, *hunk)
+		}
+		hunk = nil
+	}
+	flushFile := func() {
+		flushHunk()
+		if file != nil {
+			// Files without ---/+++ lines only have the "diff --git" names.
+			switch file.Status {
+			case "added":
+				file.OldPath = ""
+			case "deleted":
+				file.NewPath = ""
+			}
+			files = append(files, *file)
+		}
+		file = nil
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flushFile()
+			file = &DiffFile{Status: "modified"}
+			file.OldPath, file.NewPath = splitDiffGitLine(strings.TrimPrefix(line, "diff --git "))
+			continue
+		}
+		if file == nil {
+			continue
+		}
+		if hunk != nil {
+			switch {
+			case strings.HasPrefix(line, " "):
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: "context", Content: line[1:], OldNumber: oldLine, NewNumber: newLine})
+				oldLine++
+				newLine++
+				continue
+			case strings.HasPrefix(line, "-"):
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: "delete", Content: line[1:], OldNumber: oldLine})
+				oldLine++
+				continue
+			case strings.HasPrefix(line, "+"):
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: "add", Content: line[1:], NewNumber: newLine})
+				newLine++
+				continue
+			case strings.HasPrefix(line, `\`):
+				if n := len(hunk.Lines); n > 0 {
+					hunk.Lines[n-1].NoNewline = true
+				}
+				continue
+			}
+		}
+		if m := hunkHeader.FindStringSubmatch(line); m != nil {
+			flushHunk()
+			hunk = &DiffHunk{Header: line}
+			hunk.OldStart, _ = strconv.Atoi(m[1])
+			hunk.OldLines = 1
+			if m[2] != "" {
+				hunk.OldLines, _ = strconv.Atoi(m[2])
+			}
+			hunk.NewStart, _ = strconv.Atoi(m[3])
+			hunk.NewLines = 1
+			if m[4] != "" {
+				hunk.NewLines, _ = strconv.Atoi(m[4])
+			}
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "new file mode"):
+			file.Status = "added"
+		case strings.HasPrefix(line, "deleted file mode"):
+			file.Status = "deleted"
+		case strings.HasPrefix(line, "rename from "):
+			file.Status = "renamed"
+			file.OldPath = unquoteDiffPath(strings.TrimPrefix(line, "rename from "))
+		case strings.HasPrefix(line, "rename to "):
+			file.NewPath = unquoteDiffPath(strings.TrimPrefix(line, "rename to "))
+		case strings.HasPrefix(line, "copy from "):
+			file.Status = "copied"
+			file.OldPath = unquoteDiffPath(strings.TrimPrefix(line, "copy from "))
+		case strings.HasPrefix(line, "copy to "):
+			file.NewPath = unquoteDiffPath(strings.TrimPrefix(line, "copy to "))
+		case strings.HasPrefix(line, "similarity index "):
+			file.Similarity, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(line, "similarity index "), "%"))
+		case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+			file.Binary = true
+		case strings.HasPrefix(line, "--- "):
+			file.OldPath = ""
+			if p := strings.TrimPrefix(line, "--- "); p != "/dev/null" {
+				file.OldPath = strings.TrimPrefix(unquoteDiffPath(p), "a/")
+			}
+		case strings.HasPrefix(line, "+++ "):
+			file.NewPath = ""
+			if p := strings.TrimPrefix(line, "+++ "); p != "/dev/null" {
+				file.NewPath = strings.TrimPrefix(unquoteDiffPath(p), "b/")
+			}
+		}
+	}
+	flushFile()
+	return files
+}
+
+// splitDiffGitLine extracts the paths from the "a/x b/y" part of a
+// "diff --git" line. It is only a fallback for files without ---/+++ lines
+// (binary files, mode changes), so ambiguous unquoted names with " b/" are
+// split at the midpoint, which is right when both names are equal.
+func splitDiffGitLine(rest string) (string, string) {
+	if strings.HasPrefix(rest, `"`) {
+		if end := closingQuote(rest); end > 0 {
+			left := unquoteDiffPath(rest[:end+1])
+			right := unquoteDiffPath(strings.TrimSpace(rest[end+1:]))
+			return strings.TrimPrefix(left, "a/"), strings.TrimPrefix(right, "b/")
+		}
+	}
+	if len(rest)%2 == 1 {
+		mid := len(rest) / 2
+		if rest[mid] == ' ' && strings.HasPrefix(rest[mid+1:], "b/") {
+			return strings.TrimPrefix(rest[:mid], "a/"), strings.TrimPrefix(rest[mid+1:], "b/")
+		}
+	}
+	if i := strings.Index(rest, " b/"); i >= 0 {
+		return strings.TrimPrefix(rest[:i], "a/"), rest[i+3:]
+	}
+	return rest, rest
+}
+
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// unquoteDiffPath undoes git's C-style quoting of unusual path names.
+func unquoteDiffPath(p string) string {
+	p = strings.TrimSuffix(p, "\t")
+	if strings.HasPrefix(p, `"`) {
+		if u, err := strconv.Unquote(p); err == nil {
+			return u
+		}
+	}
+	return p
+}
+
+// alignHunk pairs removed and added lines for a side-by-side view. Each run
+// of removals followed by additions is zipped row by row; context lines
+// appear on both sides.
+func alignHunk(lines []DiffLine, wordDiff bool) []SideBySideRow {
+	var rows []SideBySideRow
+	for i := 0; i < len(lines); {
+		if lines[i].Kind == "context" {
+			rows = append(rows, SideBySideRow{Left: &lines[i], Right: &lines[i]})
+			i++
+			continue
+		}
+		var dels, adds []int
+		for i < len(lines) && lines[i].Kind == "delete" {
+			dels = append(dels, i)
+			i++
+		}
+		for i < len(lines) && lines[i].Kind == "add" {
+			adds = append(adds, i)
+			i++
+		}
+		for k := 0; k < len(dels) || k < len(adds); k++ {
+			var row SideBySideRow
+			if k < len(dels) {
+				row.Left = &lines[dels[k]]
+			}
+			if k < len(adds) {
+				row.Right = &lines[adds[k]]
+			}
+			if wordDiff && row.Left != nil && row.Right != nil {
+				row.Left.Segments, row.Right.Segments = wordDiffSegments(row.Left.Content, row.Right.Content)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+var wordToken = regexp.MustCompile(`\w+|\s+|[^\w\s]`)
+
+// maxWordDiffTokens bounds the LCS table built by wordDiffSegments.
+const maxWordDiffTokens = 400
+
+// wordDiffSegments splits old and new into words and marks the words that
+// are not part of their longest common subsequence.
+func wordDiffSegments(old, new string) ([]WordSegment, []WordSegment) {
+	a := wordToken.FindAllString(old, -1)
+	b := wordToken.FindAllString(new, -1)
+	if len(a) > maxWordDiffTokens || len(b) > maxWordDiffTokens {
+		return []WordSegment{{Text: old, Changed: true}}, []WordSegment{{Text: new, Changed: true}}
+	}
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var left, right []WordSegment
+	add := func(segs []WordSegment, text string, changed bool) []WordSegment {
+		if n := len(segs); n > 0 && segs[n-1].Changed == changed {
+			segs[n-1].Text += text
+			return segs
+		}
+		return append(segs, WordSegment{Text: text, Changed: changed})
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			left = add(left, a[i], false)
+			right = add(right, b[j], false)
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			right = add(right, b[j], true)
+			j++
+		default:
+			left = add(left, a[i], true)
+			i++
+		}
+	}
+	return left, right
+}
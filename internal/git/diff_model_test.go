@@ -0,0 +1,154 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@ package main
+ package main
+-var x = 1
+-var y = 2
++var x = 10
+ func main() {}
++// trailing
+\ No newline at end of file
+diff --git a/old name.txt b/new name.txt
+similarity index 90%
+rename from old name.txt
+rename to new name.txt
+diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..3333333
+Binary files /dev/null and b/logo.png differ
+`
+
+func TestParseUnifiedDiff(t *testing.T) {
+	files := parseUnifiedDiff(sampleDiff)
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %d: %+v", len(files), files)
+	}
+	f := files[0]
+	if f.Status != "modified" || f.OldPath != "main.go" || len(f.Hunks) != 1 {
+		t.Fatalf("unexpected first file: %+v", f)
+	}
+	h := f.Hunks[0]
+	if h.OldStart != 1 || h.OldLines != 4 || h.NewStart != 1 || h.NewLines != 4 || len(h.Lines) != 6 {
+		t.Fatalf("unexpected hunk: %+v", h)
+	}
+	if l := h.Lines[3]; l.Kind != "add" || l.Content != "var x = 10" || l.NewNumber != 2 {
+		t.Errorf("unexpected added line: %+v", l)
+	}
+	if l := h.Lines[5]; !l.NoNewline || l.NewNumber != 4 {
+		t.Errorf("expected last line to be flagged without newline: %+v", l)
+	}
+	if r := files[1]; r.Status != "renamed" || r.OldPath != "old name.txt" || r.NewPath != "new name.txt" || r.Similarity != 90 {
+		t.Errorf("unexpected rename: %+v", r)
+	}
+	if b := files[2]; b.Status != "added" || !b.Binary || b.NewPath != "logo.png" || b.OldPath != "" {
+		t.Errorf("unexpected binary file: %+v", b)
+	}
+}
+
+func TestAlignHunk(t *testing.T) {
+	files := parseUnifiedDiff(sampleDiff)
+	rows := alignHunk(files[0].Hunks[0].Lines, true)
+	if len(rows) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(rows))
+	}
+	if rows[1].Left.Content != "var x = 1" || rows[1].Right.Content != "var x = 10" {
+		t.Errorf("expected first removal paired with addition: %+v", rows[1])
+	}
+	if rows[2].Left.Content != "var y = 2" || rows[2].Right != nil {
+		t.Errorf("expected unpaired removal: %+v", rows[2])
+	}
+	if rows[4].Left != nil || rows[4].Right.Content != "// trailing" {
+		t.Errorf("expected unpaired addition: %+v", rows[4])
+	}
+	left := rows[1].Left.Segments
+	right := rows[1].Right.Segments
+	if len(left) != 2 || left[1].Text != "1" || !left[1].Changed || len(right) != 2 || right[1].Text != "10" {
+		t.Errorf("unexpected word segments: %+v / %+v", left, right)
+	}
+}
+
+func TestDiffRefs(t *testing.T) {
+	dir := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello  world\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "commit", "-am", "second")
+	gitRun(t, dir, "mv", "README.md", "DOCS.md")
+	gitRun(t, dir, "commit", "-m", "rename")
+
+	files, err := DiffRefs(dir, "HEAD~1", "HEAD", nil, DiffOptions{DetectRenames: true, RenameThreshold: 90})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Status != "renamed" || files[0].NewPath != "DOCS.md" {
+		t.Fatalf("unexpected diff: %+v", files)
+	}
+
+	files, err = DiffRefs(dir, "HEAD~2", "HEAD~1", []string{"README.md"}, DiffOptions{ContextLines: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || len(files[0].Hunks) != 1 || !strings.Contains(files[0].Hunks[0].Lines[1].Content, "hello  world") {
+		t.Fatalf("unexpected diff: %+v", files)
+	}
+	if _, err := DiffRefs(dir, "HEAD", "", nil, DiffOptions{IgnoreWhitespace: "bogus"}); err == nil {
+		t.Error("expected unknown whitespace mode to be rejected")
+	}
+}
+
+func TestDiffRefsIgnoresPrefixConfig(t *testing.T) {
+	dir := initTestRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, "b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	guide := filepath.Join(dir, "b", "guide.md")
+	if err := os.WriteFile(guide, []byte("guide\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-m", "guide")
+	if err := os.WriteFile(guide, []byte("guide v2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// HEAD against the working tree gets c/ and w/ with mnemonic prefixes.
+	for _, setting := range []string{"diff.noprefix", "diff.mnemonicPrefix"} {
+		gitRun(t, dir, "config", setting, "true")
+		files, err := DiffRefs(dir, "HEAD", "", nil, DiffOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) != 1 || files[0].OldPath != "b/guide.md" || files[0].NewPath != "b/guide.md" {
+			t.Errorf("%s: unexpected diff: %+v", setting, files)
+		}
+		gitRun(t, dir, "config", "--unset", setting)
+	}
+}
+
+func TestHighlightDiffFile(t *testing.T) {
+	files := parseUnifiedDiff(sampleDiff)
+	highlightDiffFile(&files[0])
+	for _, l := range files[0].Hunks[0].Lines {
+		if len(l.Spans) == 0 {
+			t.Fatalf("line not highlighted: %+v", l)
+		}
+	}
+	if s := files[0].Hunks[0].Lines[0].Spans[0]; s.Text != "package" || s.Class != "keyword" {
+		t.Errorf("unexpected first span: %+v", s)
+	}
+	highlightDiffFile(&files[1])
+	if len(files[1].Hunks) != 0 {
+		t.Errorf("rename without content should have no hunks")
+	}
+}
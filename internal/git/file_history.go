@@ -0,0 +1,86 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FileRevision is one commit in the history of a file.
+type FileRevision struct {
+	CommitSummary
+	// Path is the file's path in this commit. OldPath is set when the
+	// commit renamed or copied it from another path.
+	Path    string
+	OldPath string
+	// Status is the name-status letter: "A", "M", "D", "R" or "C".
+	Status     string
+	Similarity int
+}
+
+// FileHistory lists the commits that touched path, newest first, following
+// it across renames. limit caps the number of commits; zero means no limit.
+func FileHistory(repoPath, path string, limit int) ([]FileRevision, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	path = filepath.ToSlash(strings.TrimSpace(path))
+	if path == "" {
+		return nil, errors.New("a path is required")
+	}
+	args := []string{"-C", repoPath, "-c", "core.quotePath=false", "log", "--follow", "--name-status",
+		"--format=%x1e%H%x00%h%x00%an%x00%ae%x00%at%x00%s"}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	args = append(args, "--", path)
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("file history failed: %v\n%s", err, string(out))
+	}
+	return parseFileHistory(string(out)), nil
+}
+
+// parseFileHistory parses `git log --name-status` output whose records
+// start with \x1e and a header of NUL-separated commit fields.
+func parseFileHistory(out string) []FileRevision {
+	var revs []FileRevision
+	for _, rec := range strings.Split(out, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(rec), "\n")
+		f := strings.SplitN(lines[0], "\x00", 6)
+		if len(f) < 6 {
+			continue
+		}
+		rev := FileRevision{CommitSummary: commitSummaryFields(f)}
+		for _, line := range lines[1:] {
+			parts := strings.Split(line, "\t")
+			if len(parts) < 2 || parts[0] == "" {
+				continue
+			}
+			rev.Status = parts[0][:1]
+			rev.Similarity, _ = strconv.Atoi(parts[0][1:])
+			rev.Path = unquoteDiffPath(parts[len(parts)-1])
+			if len(parts) == 3 {
+				rev.OldPath = unquoteDiffPath(parts[1])
+			}
+		}
+		revs = append(revs, rev)
+	}
+	return revs
+}
+
+// FileAtRevision returns the contents of path as of rev. Use the Path of a
+// FileRevision to read a file from before it was renamed. An empty rev reads
+// the working tree copy.
+func FileAtRevision(repoPath, path, rev string) (string, error) {
+	content, err := readRepoFile(repoPath, path, rev)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileHistoryFollowsRenames(t *testing.T) {
+	dir := initTestRepo(t)
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("old name.txt", "one\ntwo\nthree\nfour\n")
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "add file")
+	write("old name.txt", "one\ntwo\nthree\nfour\nfive\n")
+	gitRun(t, dir, "commit", "-q", "-am", "extend file")
+	gitRun(t, dir, "mv", "old name.txt", "new.txt")
+	gitRun(t, dir, "commit", "-q", "-m", "rename file")
+	write("new.txt", "one\ntwo\nthree\nfour\nfive\nsix\n")
+	gitRun(t, dir, "commit", "-q", "-am", "extend again")
+
+	revs, err := FileHistory(dir, "new.txt", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 4 {
+		t.Fatalf("expected 4 revisions, got %+v", revs)
+	}
+	want := []struct{ subject, status, path, oldPath string }{
+		{"extend again", "M", "new.txt", ""},
+		{"rename file", "R", "new.txt", "old name.txt"},
+		{"extend file", "M", "old name.txt", ""},
+		{"add file", "A", "old name.txt", ""},
+	}
+	for i, w := range want {
+		r := revs[i]
+		if r.Subject != w.subject || r.Status != w.status || r.Path != w.path || r.OldPath != w.oldPath {
+			t.Errorf("revision %d = %+v, want %+v", i, r, w)
+		}
+	}
+	if revs[1].Similarity == 0 {
+		t.Errorf("expected a rename similarity: %+v", revs[1])
+	}
+
+	content, err := FileAtRevision(dir, revs[3].Path, revs[3].Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != "one\ntwo\nthree\nfour\n" {
+		t.Errorf("unexpected content: %q", content)
+	}
+
+	limited, err := FileHistory(dir, "new.txt", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("expected 1 revision, got %d", len(limited))
+	}
+}
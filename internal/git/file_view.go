@@ -0,0 +1,71 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/gitscope/internal/highlight"
+)
+
+// FileView is the contents of a file prepared for display.
+type FileView struct {
+	highlight.Result
+	// LFS is set, and Lines left empty, when the file is an LFS pointer
+	// rather than the content it stands for.
+	LFS *LFSObject
+}
+
+// HighlightFile returns the syntax-highlighted contents of path, a file
+// relative to the repository root. An empty rev reads the working tree copy;
+// otherwise the file is read as it was at rev. Unknown languages and files
+// that are binary, too large or minified come back as plain text, and LFS
+// pointers as the object they point to.
+func HighlightFile(repoPath, path, rev string) (FileView, error) {
+	content, err := readRepoFile(repoPath, path, rev)
+	if err != nil {
+		return FileView{}, err
+	}
+	if obj := lfsPointerObject(repoPath, path, content); obj != nil {
+		return FileView{Result: highlight.Result{Plain: true}, LFS: obj}, nil
+	}
+	return FileView{Result: highlight.Highlight(path, content)}, nil
+}
+
+// readRepoFile reads path from the working tree, or from rev when it is set.
+func readRepoFile(repoPath, path, rev string) ([]byte, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	path, err := cleanRepoPath(path)
+	if err != nil {
+		return nil, err
+	}
+	rev = strings.TrimSpace(rev)
+	if rev == "" {
+		return os.ReadFile(filepath.Join(repoPath, filepath.FromSlash(path)))
+	}
+	if strings.HasPrefix(rev, "-") {
+		return nil, errors.New("invalid revision")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "show", rev+":"+path)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s at %s failed: %v", path, rev, err)
+	}
+	return out, nil
+}
+
+// cleanRepoPath normalizes a repository-relative file path and rejects
+// paths that are absolute or lead outside the repository.
+func cleanRepoPath(path string) (string, error) {
+	path = filepath.ToSlash(filepath.Clean(strings.TrimSpace(path)))
+	if path == "." || path == "" || filepath.IsAbs(path) || strings.HasPrefix(path, "/") || path == ".." || strings.HasPrefix(path, "../") {
+		return "", fmt.Errorf("invalid path %q", path)
+	}
+	return path, nil
+}
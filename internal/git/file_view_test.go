@@ -0,0 +1,31 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHighlightFile(t *testing.T) {
+	dir := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	res, err := HighlightFile(dir, "main.go", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Language != "go" || res.Plain || res.Lines[0][0].Class != "keyword" {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	res, err = HighlightFile(dir, "README.md", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Plain || res.Lines[0][0].Text != "hello" {
+		t.Errorf("unexpected README result: %+v", res)
+	}
+	if _, err := HighlightFile(dir, "../outside.go", ""); err == nil {
+		t.Error("expected paths outside the repository to be rejected")
+	}
+}
@@ -33,6 +33,8 @@ func Status(option string) (string, error) {
 		args = append(args, "-s")
 	case "Branch (-b)":
 		args = append(args, "-b")
+	case "Submodules":
+		args = append(args, "--ignore-submodules=none")
 	default:
 		// Standard status
 	}
@@ -40,53 +42,30 @@ func Status(option string) (string, error) {
 	cmd := exec.Command("git", args...)
 	hideWindow(cmd)
 	out, err := cmd.CombinedOutput()
+	if err != nil || option != "Submodules" {
+		return string(out), err
+	}
+	// List every submodule's checked-out commit: "-" not initialized,
+	// "+" differs from the recorded commit, "U" conflicted.
+	sub := exec.Command("git", "-C", repo, "submodule", "status", "--recursive")
+	hideWindow(sub)
+	subOut, err := sub.CombinedOutput()
+	if len(subOut) > 0 {
+		out = append(out, "\nSubmodules:\n"...)
+		out = append(out, subOut...)
+	}
 	return string(out), err
 }
 
 // Commit creates a new commit with the given message.
 func Commit(msg, option string) (string, error) {
-	repo := state.RepoPath
-	if err := validateGitRepo(repo); err != nil {
-		return "", err
-	}
-
-	msg = strings.TrimSpace(msg)
-	if msg == "" {
-		return "", errors.New("commit message cannot be empty")
-	}
-
-	args := []string{"-C", repo, "commit", "-m", msg}
-	skipStageCheck := false
-
+	var opts CommitOptions
 	if option == "Stage All (-a)" {
-		args = append(args, "-a")
-		skipStageCheck = true
+		opts.StageAll = true
 	} else if option == "Amend (--amend)" {
-		args = append(args, "--amend")
-		skipStageCheck = true
-	}
-
-	if !skipStageCheck {
-		statusCmd := exec.Command("git", "-C", repo, "diff", "--cached", "--quiet")
-		hideWindow(statusCmd)
-		if err := statusCmd.Run(); err == nil {
-			return "", errors.New("no staged changes to commit")
-		}
+		opts.Amend = true
 	}
-
-	cmd := exec.Command("git", args...)
-	hideWindow(cmd)
-	out, err := cmd.CombinedOutput()
-
-	if err != nil {
-		return string(out), fmt.Errorf("commit failed: %v\n%s", err, string(out))
-	}
-
-	if len(out) == 0 {
-		return "Commit completed successfully.", nil
-	}
-
-	return string(out), nil
+	return CommitWithOptions(state.RepoPath, msg, opts)
 }
 
 // Stage adds files to the Git index based on the provided option.
@@ -231,8 +210,9 @@ func Clone(repoPath, cloneURL string) (string, error) {
 		return "", errors.New("invalid parent directory path")
 	}
 
-	// Clone into repoPath by running from its parent dir with the target name.
-	cmd := exec.Command("git", "-C", parentDir, "clone", cloneURL, filepath.Base(repoPath))
+	// Clone into repoPath by running from its parent dir with the target name,
+	// checking out any submodules along with it.
+	cmd := exec.Command("git", "-C", parentDir, "clone", "--recurse-submodules", cloneURL, filepath.Base(repoPath))
 
 	// Hide window on Windows
 	hideWindow(cmd)
@@ -245,28 +225,109 @@ func Clone(repoPath, cloneURL string) (string, error) {
 	return "successfully cloned the repo", nil
 }
 
+// CreateBranch creates a local branch at HEAD without switching to it.
+// Publishing the branch to a remote is a separate step; see PublishBranch.
 func CreateBranch(repoPath, branchname string) (string, error) {
+	return CreateBranchWithOptions(repoPath, branchname, BranchOptions{})
+}
+
+// BranchOptions controls how CreateBranchWithOptions creates a branch.
+type BranchOptions struct {
+	// StartPoint is the commit, tag or branch to start from. Empty means HEAD.
+	StartPoint string
+	// Checkout switches to the new branch after creating it.
+	Checkout bool
+	// Orphan creates a branch with no history. It implies Checkout and
+	// cannot be combined with StartPoint.
+	Orphan bool
+}
 
+// CreateBranchWithOptions creates a branch named branchname according to opts.
+func CreateBranchWithOptions(repoPath, branchname string, opts BranchOptions) (string, error) {
 	repo := repoPath
 	if err := validateRepoPath(repo); err != nil {
 		return "", err
 	}
-	cmd := exec.Command("git", "-C", repo, "branch", branchname)
+	branchname = strings.TrimSpace(branchname)
+	if err := ValidateBranchName(repo, branchname); err != nil {
+		return "", err
+	}
+	start := strings.TrimSpace(opts.StartPoint)
+	if strings.HasPrefix(start, "-") {
+		return "", errors.New("invalid start point")
+	}
+
+	var args []string
+	switch {
+	case opts.Orphan:
+		if start != "" {
+			return "", errors.New("an orphan branch cannot have a start point")
+		}
+		args = []string{"-C", repo, "switch", "--orphan", branchname}
+	case opts.Checkout:
+		args = []string{"-C", repo, "switch", "-c", branchname}
+	default:
+		args = []string{"-C", repo, "branch", branchname}
+	}
+	if start != "" {
+		args = append(args, start)
+	}
+
+	cmd := exec.Command("git", args...)
 	hideWindow(cmd)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return string(out), fmt.Errorf("Creating New Branch failed:%v\n%s", err, string(out))
 	}
-	// Set upstream to origin/branchname
-	pushCmd := exec.Command("git", "-C", repo, "push", "-u", "origin", branchname)
-	hideWindow(pushCmd)
-	pushOut, pushErr := pushCmd.CombinedOutput()
-	if pushErr != nil {
-		return string(pushOut), fmt.Errorf("Creating New Branch succeeded, but setting upstream failed:%v\n%s", pushErr, string(pushOut))
+	if opts.Orphan || opts.Checkout {
+		return "successfully Created and switched to branch " + branchname, nil
+	}
+	return "successfully Created New Branch " + branchname, nil
+}
+
+// ValidateBranchName checks name against Git's branch naming rules using
+// `git check-ref-format --branch`.
+func ValidateBranchName(repoPath, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("branch name cannot be empty")
+	}
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid branch name %q", name)
 	}
-	return "successfully Created New Branch and set upstream", nil
+	cmd := exec.Command("git", "-C", repoPath, "check-ref-format", "--branch", name)
+	hideWindow(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+	return nil
+}
 
+// PublishBranch pushes branch to remote and sets it as the upstream.
+// An empty remote defaults to origin.
+func PublishBranch(repoPath, remote, branch string) (string, error) {
+	if err := validateRepoPath(repoPath); err != nil {
+		return "", err
+	}
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		remote = "origin"
+	}
+	branch = strings.TrimSpace(branch)
+	if branch == "" {
+		return "", errors.New("branch name cannot be empty")
+	}
+	if strings.HasPrefix(remote, "-") || strings.HasPrefix(branch, "-") {
+		return "", errors.New("invalid remote or branch name")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "push", "-u", remote, branch)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("publishing branch failed: %v\n%s", err, string(out))
+	}
+	return "Published " + branch + " to " + remote + " and set upstream", nil
 }
+
 func DeleteBranch(repoPath, branchname string) (string, error) {
 	repo := repoPath
 	if err := validateRepoPath(repo); err != nil {
@@ -777,6 +838,9 @@ func LsFiles(repoPath, option string) (string, error) {
 		args = append(args, "--deleted")
 	case "Staged":
 		args = append(args, "--stage")
+	case "Sparse (-t)":
+		// Tags each file; "S" marks files outside the sparse checkout.
+		args = append(args, "-t")
 	default:
 		// Default showing all files
 	}
@@ -809,41 +873,57 @@ func Blame(repoPath, file string) (string, error) {
 	return string(out), nil
 }
 
-// Worktree manages working trees
+// Worktree manages working trees. Arguments are split at whitespace; quote
+// paths that contain spaces.
 func Worktree(repoPath, action, argsStr string) (string, error) {
 	if err := validateRepoPath(repoPath); err != nil {
 		return "", err
 	}
 
-	args := []string{"-C", repoPath, "worktree"}
+	parts, err := splitArgs(argsStr)
+	if err != nil {
+		return "", err
+	}
 
 	switch action {
 	case "List":
-		args = append(args, "list")
+		return runWorktree(repoPath, "list")
 	case "Add":
-		parts := strings.Fields(argsStr)
-		if len(parts) < 2 {
-			return "", errors.New("usage: worktree add <path> <branch>")
+		if len(parts) == 0 || len(parts) > 2 {
+			return "", errors.New("usage: worktree add <path> [<branch>]")
+		}
+		opts := WorktreeAddOptions{Path: parts[0]}
+		if len(parts) == 2 {
+			opts.Commit = parts[1]
 		}
-		args = append(args, "add", parts[0], parts[1])
+		return AddWorktree(repoPath, opts)
 	case "Remove":
-		if argsStr == "" {
+		if len(parts) != 1 {
+			return "", errors.New("worktree name/path required")
+		}
+		return RemoveWorktree(repoPath, parts[0], false)
+	case "Lock":
+		if len(parts) == 0 {
+			return "", errors.New("usage: worktree lock <path> [reason]")
+		}
+		return LockWorktree(repoPath, parts[0], strings.Join(parts[1:], " "))
+	case "Unlock":
+		if len(parts) != 1 {
 			return "", errors.New("worktree name/path required")
 		}
-		args = append(args, "remove", argsStr)
+		return UnlockWorktree(repoPath, parts[0])
+	case "Move":
+		if len(parts) != 2 {
+			return "", errors.New("usage: worktree move <path> <new-path>")
+		}
+		return MoveWorktree(repoPath, parts[0], parts[1])
+	case "Repair":
+		return RepairWorktrees(repoPath, parts)
 	case "Prune":
-		args = append(args, "prune")
+		return PruneWorktrees(repoPath)
 	default:
 		return "", errors.New("unknown worktree action")
 	}
-
-	cmd := exec.Command("git", args...)
-	hideWindow(cmd)
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		return string(out), fmt.Errorf("worktree failed: %v\n%s", err, string(out))
-	}
-	return string(out), nil
 }
 
 // Shortlog shows commit summary in a user-friendly format
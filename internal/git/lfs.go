@@ -0,0 +1,431 @@
+package git
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrLFSNotInstalled is returned by LFS operations that need the git-lfs
+// extension when it is not installed.
+var ErrLFSNotInstalled = errors.New("git-lfs is not installed")
+
+// lfsPointerVersion starts every LFS pointer file.
+const lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+
+// LFSPattern is a .gitattributes pattern stored in LFS.
+type LFSPattern struct {
+	Pattern string
+	// Source is the .gitattributes file declaring it, relative to the root.
+	Source   string
+	Lockable bool
+}
+
+// LFSStatus describes LFS use in a repository.
+type LFSStatus struct {
+	// Installed reports whether git-lfs is available; Version is its
+	// version line.
+	Installed bool
+	Version   string
+	// Used is set when .gitattributes stores any pattern in LFS.
+	Used     bool
+	Patterns []LFSPattern
+	// Endpoint is the LFS server URL reported by `git lfs env`.
+	Endpoint string
+}
+
+// LFSObject is a file stored in LFS.
+type LFSObject struct {
+	Path string
+	OID  string
+	Size int64
+	// Downloaded is set when the object is in the local LFS store;
+	// CheckedOut when the working tree holds the real content rather than
+	// the pointer.
+	Downloaded bool
+	CheckedOut bool
+}
+
+// LFSLock is a file lock held on the LFS server.
+type LFSLock struct {
+	ID       string
+	Path     string
+	Owner    string
+	LockedAt time.Time
+}
+
+// lfsVersion returns the `git lfs version` line, or "" if git-lfs is missing.
+func lfsVersion() string {
+	cmd := exec.Command("git", "lfs", "version")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// lfsCommand prepares `git lfs <args>` in repoPath.
+func lfsCommand(repoPath string, args ...string) (*exec.Cmd, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	if lfsVersion() == "" {
+		return nil, ErrLFSNotInstalled
+	}
+	cmd := exec.Command("git", append([]string{"-C", repoPath, "lfs"}, args...)...)
+	hideWindow(cmd)
+	return cmd, nil
+}
+
+// runLFS runs `git lfs <args>` in repoPath.
+func runLFS(repoPath string, args ...string) (string, error) {
+	cmd, err := lfsCommand(repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("lfs %s failed: %v\n%s", args[0], err, string(out))
+	}
+	return string(out), nil
+}
+
+// GetLFSStatus reports whether git-lfs is installed and which patterns the
+// repository stores in LFS. Patterns are read from .gitattributes, so they
+// are listed even without git-lfs.
+func GetLFSStatus(repoPath string) (LFSStatus, error) {
+	var st LFSStatus
+	if err := validateGitRepo(repoPath); err != nil {
+		return st, err
+	}
+	st.Version = lfsVersion()
+	st.Installed = st.Version != ""
+
+	files := exec.Command("git", "-C", repoPath, "-c", "core.quotePath=false", "ls-files", "-z", "--cached", "--others", "--exclude-standard", "--", ".gitattributes", "*/.gitattributes")
+	hideWindow(files)
+	out, err := files.Output()
+	if err != nil {
+		return st, fmt.Errorf("listing attributes files failed: %v", err)
+	}
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name == "" {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(repoPath, filepath.FromSlash(name)))
+		if err != nil {
+			continue
+		}
+		st.Patterns = append(st.Patterns, parseLFSAttributes(string(content), name)...)
+	}
+	st.Used = len(st.Patterns) > 0
+
+	if st.Installed {
+		env := exec.Command("git", "-C", repoPath, "lfs", "env")
+		hideWindow(env)
+		if out, err := env.Output(); err == nil {
+			st.Endpoint = parseLFSEndpoint(string(out))
+		}
+	}
+	return st, nil
+}
+
+// parseLFSAttributes returns the patterns of a .gitattributes file that set
+// filter=lfs.
+func parseLFSAttributes(content, source string) []LFSPattern {
+	var patterns []LFSPattern
+	for _, line := range strings.Split(content, "\n") {
+		f := strings.Fields(line)
+		if len(f) < 2 || strings.HasPrefix(f[0], "#") {
+			continue
+		}
+		p := LFSPattern{Pattern: f[0], Source: source}
+		lfs := false
+		for _, attr := range f[1:] {
+			switch attr {
+			case "filter=lfs":
+				lfs = true
+			case "lockable":
+				p.Lockable = true
+			}
+		}
+		if lfs {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// parseLFSEndpoint extracts the URL from the "Endpoint=<url> (auth=...)"
+// line of `git lfs env`.
+func parseLFSEndpoint(out string) string {
+	for _, line := range strings.Split(out, "\n") {
+		if rest, ok := strings.CutPrefix(line, "Endpoint="); ok {
+			url, _, _ := strings.Cut(rest, " ")
+			return url
+		}
+	}
+	return ""
+}
+
+// ListLFSObjects lists the files in the index stored in LFS, reading their
+// pointers directly so it works without git-lfs.
+func ListLFSObjects(repoPath string) ([]LFSObject, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	objects := []LFSObject{}
+	cmd := exec.Command("git", "-C", repoPath, "-c", "core.quotePath=false", "ls-files", "-s", "-z", "--", ":(attr:filter=lfs)")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing LFS files failed: %v", err)
+	}
+	var paths, hashes []string
+	seen := make(map[string]bool)
+	for _, rec := range strings.Split(string(out), "\x00") {
+		meta, path, ok := strings.Cut(rec, "\t")
+		f := strings.Fields(meta)
+		// An unmerged file has one entry per stage; list it once.
+		if !ok || len(f) < 2 || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+		hashes = append(hashes, f[1])
+	}
+	if len(paths) == 0 {
+		return objects, nil
+	}
+
+	blobs, err := catFileBatch(repoPath, hashes)
+	if err != nil {
+		return nil, err
+	}
+	for i, path := range paths {
+		obj := lfsPointerObject(repoPath, path, []byte(blobs[i]))
+		if obj == nil {
+			// Matched by the attribute but committed without the filter.
+			continue
+		}
+		if content, err := readHead(filepath.Join(repoPath, filepath.FromSlash(path)), len(lfsPointerVersion)); err == nil {
+			obj.CheckedOut = content != lfsPointerVersion
+		}
+		objects = append(objects, *obj)
+	}
+	return objects, nil
+}
+
+// catFileBatch reads the blobs with the given hashes in one
+// `git cat-file --batch` call. Blobs larger than 1 KiB cannot be pointers
+// and are returned empty.
+func catFileBatch(repoPath string, hashes []string) ([]string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "cat-file", "--batch")
+	hideWindow(cmd)
+	cmd.Stdin = strings.NewReader(strings.Join(hashes, "\n") + "\n")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("cat-file failed: %v", err)
+	}
+	r := bufio.NewReader(stdout)
+	blobs := make([]string, len(hashes))
+	for i := range hashes {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			break
+		}
+		f := strings.Fields(header)
+		if len(f) < 3 {
+			// "<hash> missing"
+			continue
+		}
+		size, _ := strconv.ParseInt(f[2], 10, 64)
+		if size > 1024 {
+			if _, err := io.CopyN(io.Discard, r, size+1); err != nil {
+				break
+			}
+			continue
+		}
+		data := make([]byte, size+1) // content and trailing newline
+		if _, err := io.ReadFull(r, data); err != nil {
+			break
+		}
+		blobs[i] = string(data[:size])
+	}
+	_ = cmd.Wait()
+	return blobs, nil
+}
+
+// parseLFSPointer parses an LFS pointer file, returning its sha256 oid and size.
+func parseLFSPointer(content string) (string, int64, bool) {
+	if !strings.HasPrefix(content, lfsPointerVersion+"\n") {
+		return "", 0, false
+	}
+	var oid string
+	var size int64 = -1
+	for _, line := range strings.Split(content, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "oid":
+			oid = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			size, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+	return oid, size, oid != "" && size >= 0
+}
+
+// lfsPointerObject returns the LFS object content points to, or nil when
+// content is not an LFS pointer. CheckedOut is left unset.
+func lfsPointerObject(repoPath, path string, content []byte) *LFSObject {
+	if len(content) > 1024 {
+		return nil
+	}
+	oid, size, ok := parseLFSPointer(string(content))
+	if !ok {
+		return nil
+	}
+	obj := &LFSObject{Path: path, OID: oid, Size: size}
+	if len(oid) > 4 {
+		_, err := os.Stat(filepath.Join(gitPath(repoPath, "lfs"), "objects", oid[:2], oid[2:4], oid))
+		obj.Downloaded = err == nil
+	}
+	return obj
+}
+
+// readHead reads up to n bytes from the start of a file.
+func readHead(path string, n int) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	buf := make([]byte, n)
+	read, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return string(buf[:read]), nil
+}
+
+// validLFSArg rejects empty arguments and ones git-lfs would read as flags.
+func validLFSArg(arg string) error {
+	if strings.TrimSpace(arg) == "" || strings.HasPrefix(arg, "-") {
+		return fmt.Errorf("invalid argument %q", arg)
+	}
+	return nil
+}
+
+// TrackLFS stores files matching pattern in LFS by adding it to
+// .gitattributes. lockable also marks them lockable, making them read-only
+// until locked.
+func TrackLFS(repoPath, pattern string, lockable bool) (string, error) {
+	if err := validLFSArg(pattern); err != nil {
+		return "", err
+	}
+	args := []string{"track"}
+	if lockable {
+		args = append(args, "--lockable")
+	}
+	return runLFS(repoPath, append(args, pattern)...)
+}
+
+// UntrackLFS removes pattern from LFS tracking in .gitattributes.
+func UntrackLFS(repoPath, pattern string) (string, error) {
+	if err := validLFSArg(pattern); err != nil {
+		return "", err
+	}
+	return runLFS(repoPath, "untrack", pattern)
+}
+
+// PullLFS downloads and checks out LFS content for paths, or for every LFS
+// file when paths is empty.
+func PullLFS(repoPath string, paths []string) (string, error) {
+	args := []string{"pull"}
+	if len(paths) > 0 {
+		for _, p := range paths {
+			if err := validLFSArg(p); err != nil {
+				return "", err
+			}
+			if strings.Contains(p, ",") {
+				return "", fmt.Errorf("path %q cannot contain a comma", p)
+			}
+		}
+		args = append(args, "--include="+strings.Join(paths, ","), "--exclude=")
+	}
+	return runLFS(repoPath, args...)
+}
+
+// FetchRecentLFS downloads LFS objects for recent commits and branches, as
+// configured by the lfs.fetchrecent* settings, without checking them out.
+func FetchRecentLFS(repoPath string) (string, error) {
+	return runLFS(repoPath, "fetch", "--recent")
+}
+
+// LockLFS locks path on the LFS server.
+func LockLFS(repoPath, path string) (string, error) {
+	if err := validLFSArg(path); err != nil {
+		return "", err
+	}
+	return runLFS(repoPath, "lock", path)
+}
+
+// UnlockLFS releases the lock on path. force breaks a lock held by someone else.
+func UnlockLFS(repoPath, path string, force bool) (string, error) {
+	if err := validLFSArg(path); err != nil {
+		return "", err
+	}
+	args := []string{"unlock"}
+	if force {
+		args = append(args, "--force")
+	}
+	return runLFS(repoPath, append(args, path)...)
+}
+
+// ListLFSLocks returns the locks currently held on the LFS server.
+func ListLFSLocks(repoPath string) ([]LFSLock, error) {
+	cmd, err := lfsCommand(repoPath, "locks", "--json")
+	if err != nil {
+		return nil, err
+	}
+	// Only stdout holds the JSON; warnings and prompts go to stderr.
+	out, err := cmd.Output()
+	if err != nil {
+		msg := ""
+		if ee, ok := err.(*exec.ExitError); ok {
+			msg = string(ee.Stderr)
+		}
+		return nil, fmt.Errorf("lfs locks failed: %v\n%s", err, msg)
+	}
+	return parseLFSLocks(string(out))
+}
+
+func parseLFSLocks(out string) ([]LFSLock, error) {
+	var raw []struct {
+		ID    string `json:"id"`
+		Path  string `json:"path"`
+		Owner struct {
+			Name string `json:"name"`
+		} `json:"owner"`
+		LockedAt time.Time `json:"locked_at"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &raw); err != nil {
+		return nil, fmt.Errorf("parsing locks failed: %v", err)
+	}
+	locks := make([]LFSLock, 0, len(raw))
+	for _, l := range raw {
+		locks = append(locks, LFSLock{ID: l.ID, Path: l.Path, Owner: l.Owner.Name, LockedAt: l.LockedAt})
+	}
+	return locks, nil
+}
@@ -0,0 +1,152 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const testOID = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+
+func TestLFSWithoutExtension(t *testing.T) {
+	dir := initTestRepo(t)
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".gitattributes", "*.psd filter=lfs diff=lfs merge=lfs -text lockable\n*.txt text\n")
+	pointer := lfsPointerVersion + "\noid sha256:" + testOID + "\nsize 12345\n"
+	write("cover.psd", pointer)
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "add design")
+
+	st, err := GetLFSStatus(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.Used || len(st.Patterns) != 1 || st.Patterns[0].Pattern != "*.psd" || !st.Patterns[0].Lockable || st.Patterns[0].Source != ".gitattributes" {
+		t.Errorf("unexpected status: %+v", st)
+	}
+
+	objects, err := ListLFSObjects(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("expected one object, got %+v", objects)
+	}
+	if o := objects[0]; o.Path != "cover.psd" || o.OID != testOID || o.Size != 12345 || o.Downloaded || o.CheckedOut {
+		t.Errorf("unexpected pointer-only object: %+v", o)
+	}
+
+	store := filepath.Join(dir, ".git", "lfs", "objects", testOID[:2], testOID[2:4])
+	if err := os.MkdirAll(store, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(store, testOID), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	write("cover.psd", "real content")
+	objects, _ = ListLFSObjects(dir)
+	if o := objects[0]; !o.Downloaded || !o.CheckedOut {
+		t.Errorf("expected a downloaded, checked-out object: %+v", o)
+	}
+
+	// A conflicted pointer has an index entry per stage.
+	blob := strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD:cover.psd"))
+	info := "0 0000000000000000000000000000000000000000\tcover.psd\n"
+	for stage := 1; stage <= 3; stage++ {
+		info += "100644 " + blob + " " + string(rune('0'+stage)) + "\tcover.psd\n"
+	}
+	cmd := exec.Command("git", "-C", dir, "update-index", "--index-info")
+	cmd.Stdin = strings.NewReader(info)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("update-index failed: %v\n%s", err, out)
+	}
+	if objects, _ = ListLFSObjects(dir); len(objects) != 1 {
+		t.Errorf("expected the conflicted pointer once, got %+v", objects)
+	}
+	gitRun(t, dir, "reset", "-q")
+
+	if !st.Installed {
+		if _, err := TrackLFS(dir, "*.png", false); !errors.Is(err, ErrLFSNotInstalled) {
+			t.Errorf("expected ErrLFSNotInstalled, got %v", err)
+		}
+		if _, err := ListLFSLocks(dir); !errors.Is(err, ErrLFSNotInstalled) {
+			t.Errorf("expected ErrLFSNotInstalled, got %v", err)
+		}
+	}
+	if _, err := TrackLFS(dir, "--global", false); err == nil || errors.Is(err, ErrLFSNotInstalled) {
+		t.Errorf("expected flag-like patterns to be rejected, got %v", err)
+	}
+}
+
+func TestParseLFSHelpers(t *testing.T) {
+	if _, _, ok := parseLFSPointer("hello\n"); ok {
+		t.Error("plain text parsed as a pointer")
+	}
+	env := "git-lfs/3.4.0 (GitHub; linux amd64; go 1.21)\nEndpoint=https://example.com/repo.git/info/lfs (auth=none)\nLocalWorkingDir=/repo\n"
+	if got := parseLFSEndpoint(env); got != "https://example.com/repo.git/info/lfs" {
+		t.Errorf("unexpected endpoint: %q", got)
+	}
+	locks, err := parseLFSLocks(`[{"id":"7","path":"cover.psd","owner":{"name":"ann"},"locked_at":"2024-05-01T10:00:00Z"}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locks) != 1 || locks[0].ID != "7" || locks[0].Owner != "ann" || !strings.HasPrefix(locks[0].LockedAt.String(), "2024-05-01") {
+		t.Errorf("unexpected locks: %+v", locks)
+	}
+}
+
+func TestLFSPointersInViews(t *testing.T) {
+	dir := initTestRepo(t)
+	pointer := func(oid string, size int) string {
+		return lfsPointerVersion + "\noid sha256:" + oid + "\nsize " + strconv.Itoa(size) + "\n"
+	}
+	newOID := strings.Repeat("ab", 32)
+	if err := os.WriteFile(filepath.Join(dir, "cover.psd"), []byte(pointer(testOID, 12345)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "add design")
+	if err := os.WriteFile(filepath.Join(dir, "cover.psd"), []byte(pointer(newOID, 99)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "commit", "-q", "-am", "update design")
+
+	view, err := HighlightFile(dir, "cover.psd", "HEAD~1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.LFS == nil || view.LFS.OID != testOID || view.LFS.Size != 12345 || view.LFS.Downloaded || len(view.Lines) != 0 {
+		t.Errorf("expected an LFS object instead of text: %+v", view)
+	}
+	if view, _ := HighlightFile(dir, "README.md", ""); view.LFS != nil || len(view.Lines) != 1 {
+		t.Errorf("expected README as text: %+v", view)
+	}
+
+	files, err := DiffRefs(dir, "HEAD~1", "HEAD", nil, DiffOptions{ContextLines: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].OldLFS == nil || files[0].NewLFS == nil || len(files[0].Hunks) != 0 {
+		t.Fatalf("expected the pointer change as LFS objects: %+v", files)
+	}
+	if files[0].OldLFS.OID != testOID || files[0].NewLFS.OID != newOID || files[0].NewLFS.Size != 99 {
+		t.Errorf("unexpected LFS objects: %+v / %+v", files[0].OldLFS, files[0].NewLFS)
+	}
+
+	res, err := BinaryDiff(dir, "HEAD~1", "", "cover.psd", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Old.LFS == nil || res.New.LFS == nil || res.New.LFS.OID != newOID || res.Pixels != nil {
+		t.Errorf("expected LFS objects on both sides: %+v", res)
+	}
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package git
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// killProcessTree makes cancelling cmd kill the process tree it starts, so
+// that children such as a user's test script stop with it.
+func killProcessTree(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= syscall.CREATE_NEW_PROCESS_GROUP
+	cmd.Cancel = func() error {
+		kill := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid))
+		hideWindow(kill)
+		if err := kill.Run(); err != nil {
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,185 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// RestoreOptions controls RestoreFromRevision.
+type RestoreOptions struct {
+	// Target is "worktree" (the default), "index" or "both".
+	Target string
+	// Force overwrites uncommitted changes to the restored paths.
+	Force bool
+}
+
+// RestoreChange is a file RestoreFromRevision would change.
+type RestoreChange struct {
+	Path string
+	// Action is "add", "modify" or "delete": what restoring does to the
+	// file in the target.
+	Action string
+}
+
+// RestorePreview lists what restoring paths from a revision would change.
+type RestorePreview struct {
+	Changes []RestoreChange
+	// Uncommitted lists restored paths whose uncommitted changes in the
+	// target would be lost.
+	Uncommitted []string
+}
+
+// restoreFlags maps a restore target to `git restore` flags.
+func restoreFlags(target string) ([]string, error) {
+	switch target {
+	case "", "worktree":
+		return []string{"--worktree"}, nil
+	case "index":
+		return []string{"--staged"}, nil
+	case "both":
+		return []string{"--worktree", "--staged"}, nil
+	}
+	return nil, fmt.Errorf("unknown restore target: %s", target)
+}
+
+// PreviewRestore reports what RestoreFromRevision would change without
+// modifying anything.
+func PreviewRestore(repoPath, rev string, paths []string, target string) (RestorePreview, error) {
+	var preview RestorePreview
+	if err := validateGitRepo(repoPath); err != nil {
+		return preview, err
+	}
+	rev = strings.TrimSpace(rev)
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return preview, errors.New("invalid revision")
+	}
+	if err := verifyRevision(repoPath, rev); err != nil {
+		return preview, err
+	}
+	if len(paths) == 0 {
+		return preview, errors.New("no paths to restore")
+	}
+	if _, err := restoreFlags(target); err != nil {
+		return preview, err
+	}
+
+	// Diffing rev against the target shows what the target has changed
+	// since rev; restoring undoes it, so additions become deletions.
+	diffNames := func(cached bool) (string, error) {
+		args := []string{"-C", repoPath, "-c", "core.quotePath=false", "diff", "--name-status", "-z", "--no-renames"}
+		if cached {
+			args = append(args, "--cached")
+		}
+		args = append(args, rev, "--")
+		args = append(args, paths...)
+		cmd := exec.Command("git", args...)
+		hideWindow(cmd)
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("diff failed: %v", err)
+		}
+		return string(out), nil
+	}
+	out, err := diffNames(target == "index")
+	if err != nil {
+		return preview, err
+	}
+	if target == "both" {
+		cached, err := diffNames(true)
+		if err != nil {
+			return preview, err
+		}
+		out += cached
+	}
+	seen := make(map[string]bool)
+	fields := strings.Split(out, "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		status, path := fields[i], fields[i+1]
+		if status == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		action := "modify"
+		switch status[0] {
+		case 'A':
+			action = "delete"
+		case 'D':
+			action = "add"
+		}
+		preview.Changes = append(preview.Changes, RestoreChange{Path: path, Action: action})
+	}
+
+	status := exec.Command("git", append([]string{"-C", repoPath, "status", "--porcelain", "-z", "--untracked-files=all", "--"}, paths...)...)
+	hideWindow(status)
+	statusOut, err := status.Output()
+	if err != nil {
+		return preview, fmt.Errorf("status failed: %v", err)
+	}
+	// Only files the restore actually changes can lose edits.
+	for _, p := range uncommittedPaths(string(statusOut), target) {
+		if seen[p] {
+			preview.Uncommitted = append(preview.Uncommitted, p)
+		}
+	}
+	return preview, nil
+}
+
+// uncommittedPaths picks the paths from `git status --porcelain -z` whose
+// changes restoring into target would discard: staged changes for the
+// index, unstaged and untracked files for the worktree.
+func uncommittedPaths(out, target string) []string {
+	var paths []string
+	recs := strings.Split(out, "\x00")
+	for i := 0; i < len(recs); i++ {
+		rec := recs[i]
+		if len(rec) < 4 {
+			continue
+		}
+		x, y, path := rec[0], rec[1], rec[3:]
+		if x == 'R' || x == 'C' {
+			// The original path follows in its own record.
+			i++
+		}
+		staged := x != ' ' && x != '?'
+		unstaged := y != ' '
+		var lost bool
+		switch target {
+		case "index":
+			lost = staged
+		case "both":
+			lost = staged || unstaged
+		default:
+			lost = unstaged
+		}
+		if lost {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// RestoreFromRevision restores paths from rev into the working tree, the
+// index or both with `git restore --source`. Unless opts.Force is set it
+// refuses when that would discard uncommitted changes, returning the preview
+// so the caller can ask for confirmation.
+func RestoreFromRevision(repoPath, rev string, paths []string, opts RestoreOptions) (RestorePreview, error) {
+	preview, err := PreviewRestore(repoPath, rev, paths, opts.Target)
+	if err != nil {
+		return preview, err
+	}
+	if len(preview.Uncommitted) > 0 && !opts.Force {
+		return preview, fmt.Errorf("restoring would overwrite uncommitted changes in: %s", strings.Join(preview.Uncommitted, ", "))
+	}
+	flags, _ := restoreFlags(opts.Target)
+	args := append([]string{"-C", repoPath, "restore", "--source=" + strings.TrimSpace(rev)}, flags...)
+	args = append(args, "--")
+	args = append(args, paths...)
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return preview, fmt.Errorf("restore failed: %v\n%s", err, string(out))
+	}
+	return preview, nil
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRestoreFromRevision(t *testing.T) {
+	dir := initTestRepo(t)
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("docs/a.txt", "a1\n")
+	write("docs/b.txt", "b1\n")
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "add docs")
+	write("docs/a.txt", "a2\n")
+	gitRun(t, dir, "rm", "-q", "docs/b.txt")
+	write("docs/c.txt", "c2\n")
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "change docs")
+
+	preview, err := PreviewRestore(dir, "HEAD~1", []string{"docs"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actions := make(map[string]string)
+	for _, c := range preview.Changes {
+		actions[c.Path] = c.Action
+	}
+	if actions["docs/a.txt"] != "modify" || actions["docs/b.txt"] != "add" || actions["docs/c.txt"] != "delete" || len(preview.Uncommitted) != 0 {
+		t.Errorf("unexpected preview: %+v", preview)
+	}
+
+	write("docs/a.txt", "local edit\n")
+	preview, err = RestoreFromRevision(dir, "HEAD~1", []string{"docs"}, RestoreOptions{})
+	if err == nil || len(preview.Uncommitted) != 1 || preview.Uncommitted[0] != "docs/a.txt" {
+		t.Fatalf("expected uncommitted edits to be protected: %v %+v", err, preview)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "docs", "a.txt")); string(data) != "local edit\n" {
+		t.Errorf("local edit was overwritten: %q", data)
+	}
+
+	if _, err := RestoreFromRevision(dir, "HEAD~1", []string{"docs"}, RestoreOptions{Force: true}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "docs", "a.txt")); string(data) != "a1\n" {
+		t.Errorf("unexpected restored content: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "docs", "b.txt")); err != nil {
+		t.Errorf("expected b.txt to be restored: %v", err)
+	}
+	// Only the worktree was restored, so the index still matches HEAD.
+	if out := gitRun(t, dir, "diff", "--cached", "--name-only"); out != "" {
+		t.Errorf("index changed: %q", out)
+	}
+
+	if _, err := RestoreFromRevision(dir, "HEAD~1", []string{"docs/a.txt"}, RestoreOptions{Target: "index"}); err != nil {
+		t.Fatal(err)
+	}
+	if out := gitRun(t, dir, "diff", "--cached", "--name-only"); out != "docs/a.txt\n" {
+		t.Errorf("expected a.txt staged from HEAD~1, got %q", out)
+	}
+}
+
+func TestUncommittedPaths(t *testing.T) {
+	out := "M  staged.txt\x00 M edited.txt\x00?? new.txt\x00R  to.txt\x00from.txt\x00"
+	if got := uncommittedPaths(out, "worktree"); len(got) != 2 || got[0] != "edited.txt" || got[1] != "new.txt" {
+		t.Errorf("worktree: %v", got)
+	}
+	if got := uncommittedPaths(out, "index"); len(got) != 2 || got[0] != "staged.txt" || got[1] != "to.txt" {
+		t.Errorf("index: %v", got)
+	}
+	if got := uncommittedPaths(out, "both"); len(got) != 4 {
+		t.Errorf("both: %v", got)
+	}
+}
@@ -0,0 +1,348 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultSearchLimit caps search results when a query sets no limit.
+const DefaultSearchLimit = 500
+
+// CommitQuery filters history for SearchCommits. Empty fields are ignored;
+// the others must all match.
+type CommitQuery struct {
+	// Message greps commit messages.
+	Message   string
+	Author    string
+	Committer string
+	// Pickaxe finds commits that change the number of occurrences of a
+	// string (-S); PickaxeRegex finds commits whose diff has added or
+	// removed lines matching a regular expression (-G).
+	Pickaxe      string
+	PickaxeRegex string
+	// Rev is where to start walking history; empty means HEAD. AllRefs
+	// searches every branch and tag instead.
+	Rev     string
+	AllRefs bool
+	Paths   []string
+	// IgnoreCase applies to Message, Author, Committer and PickaxeRegex.
+	IgnoreCase bool
+	// Limit caps the number of commits returned; zero uses DefaultSearchLimit.
+	Limit int
+}
+
+// CommitHit is a commit matched by SearchCommits. For pickaxe searches
+// Files lists the files whose changes matched and Matches the added or
+// removed lines containing the search term.
+type CommitHit struct {
+	CommitSummary
+	Files   []string
+	Matches []PickaxeMatch
+}
+
+// PickaxeMatch is a changed line matched by a pickaxe search. Rev is the
+// commit; Line numbers the new file for added lines and the old file, i.e.
+// the commit's parent, for Removed ones.
+type PickaxeMatch struct {
+	GrepHit
+	Removed bool
+}
+
+// maxPickaxeMatches caps the lines reported per commit.
+const maxPickaxeMatches = 50
+
+// GrepQuery controls GrepFiles.
+type GrepQuery struct {
+	Pattern string
+	// Regex treats Pattern as an extended regular expression; otherwise it
+	// is matched literally.
+	Regex      bool
+	IgnoreCase bool
+	WholeWord  bool
+	// Rev searches a revision instead of the working tree.
+	Rev   string
+	Paths []string
+	// Limit caps the number of matching lines; zero uses DefaultSearchLimit.
+	Limit int
+}
+
+// GrepHit is one matching line found by GrepFiles.
+type GrepHit struct {
+	Rev    string
+	Path   string
+	Line   int
+	Column int
+	Text   string
+}
+
+// CommitSearchResult holds the commits found by SearchCommits. Truncated
+// is set when more commits matched than the limit allowed.
+type CommitSearchResult struct {
+	Hits      []CommitHit
+	Truncated bool
+}
+
+// GrepResult holds the lines found by GrepFiles. Truncated is set when more
+// lines matched than the limit allowed.
+type GrepResult struct {
+	Hits      []GrepHit
+	Truncated bool
+}
+
+func searchLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultSearchLimit
+	}
+	return limit
+}
+
+// SearchCommits searches history with `git log`. Cancelling ctx stops the
+// search and returns its error.
+func SearchCommits(ctx context.Context, repoPath string, q CommitQuery) (CommitSearchResult, error) {
+	var res CommitSearchResult
+	if err := validateGitRepo(repoPath); err != nil {
+		return res, err
+	}
+	if q.Message == "" && q.Author == "" && q.Committer == "" && q.Pickaxe == "" && q.PickaxeRegex == "" && len(q.Paths) == 0 {
+		return res, errors.New("empty search")
+	}
+	if q.Pickaxe != "" && q.PickaxeRegex != "" {
+		return res, errors.New("use either a pickaxe string or a pickaxe regex, not both")
+	}
+	limit := searchLimit(q.Limit)
+	args := []string{"-C", repoPath, "-c", "core.quotePath=false", "log",
+		"--format=%x1e%H%x00%h%x00%an%x00%ae%x00%at%x00%s", "-n", strconv.Itoa(limit + 1)}
+	if q.Message != "" {
+		args = append(args, "--grep="+q.Message)
+	}
+	if q.Author != "" {
+		args = append(args, "--author="+q.Author)
+	}
+	if q.Committer != "" {
+		args = append(args, "--committer="+q.Committer)
+	}
+	if q.IgnoreCase {
+		args = append(args, "--regexp-ignore-case")
+	}
+	if q.Pickaxe != "" {
+		args = append(args, "-S"+q.Pickaxe, "-p", "--unified=0", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/")
+	}
+	if q.PickaxeRegex != "" {
+		args = append(args, "-G"+q.PickaxeRegex, "-p", "--unified=0", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/")
+	}
+	switch rev := strings.TrimSpace(q.Rev); {
+	case q.AllRefs:
+		args = append(args, "--all")
+	case strings.HasPrefix(rev, "-"):
+		return res, errors.New("invalid revision")
+	case rev != "":
+		args = append(args, rev)
+	}
+	args = append(args, "--")
+	args = append(args, q.Paths...)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if ctx.Err() != nil {
+		return res, ctx.Err()
+	}
+	if err != nil {
+		msg := ""
+		if ee, ok := err.(*exec.ExitError); ok {
+			msg = string(ee.Stderr)
+		}
+		return res, fmt.Errorf("search failed: %v\n%s", err, msg)
+	}
+	match := pickaxeMatcher(q)
+	for _, rec := range strings.Split(string(out), "\x1e") {
+		header, patch, _ := strings.Cut(rec, "\n")
+		f := strings.SplitN(header, "\x00", 6)
+		if len(f) < 6 {
+			continue
+		}
+		hit := CommitHit{CommitSummary: commitSummaryFields(f)}
+		if match != nil {
+			hit.Files, hit.Matches = pickaxeMatches(hit.Hash, patch, match)
+		}
+		res.Hits = append(res.Hits, hit)
+	}
+	if len(res.Hits) > limit {
+		res.Hits, res.Truncated = res.Hits[:limit], true
+	}
+	return res, nil
+}
+
+// pickaxeMatcher returns a function that finds the query's pickaxe term in
+// a line and returns its 0-based byte offset, or nil when the query has no
+// pickaxe. -G patterns that Go cannot compile are matched literally.
+func pickaxeMatcher(q CommitQuery) func(string) int {
+	switch {
+	case q.PickaxeRegex != "":
+		expr := q.PickaxeRegex
+		if q.IgnoreCase {
+			expr = "(?i)" + expr
+		}
+		if re, err := regexp.Compile(expr); err == nil {
+			return func(line string) int {
+				if loc := re.FindStringIndex(line); loc != nil {
+					return loc[0]
+				}
+				return -1
+			}
+		}
+		return literalMatcher(q.PickaxeRegex, q.IgnoreCase)
+	case q.Pickaxe != "":
+		return literalMatcher(q.Pickaxe, q.IgnoreCase)
+	}
+	return nil
+}
+
+func literalMatcher(term string, ignoreCase bool) func(string) int {
+	if ignoreCase {
+		term = strings.ToLower(term)
+	}
+	return func(line string) int {
+		if ignoreCase {
+			line = strings.ToLower(line)
+		}
+		return strings.Index(line, term)
+	}
+}
+
+// pickaxeMatches parses the patch git printed for a pickaxe hit and returns
+// the files it touches and the changed lines that contain the term.
+func pickaxeMatches(rev, patch string, match func(string) int) ([]string, []PickaxeMatch) {
+	var files []string
+	var matches []PickaxeMatch
+	for _, file := range parseUnifiedDiff(patch) {
+		path := file.NewPath
+		if path == "" {
+			path = file.OldPath
+		}
+		files = append(files, path)
+		for _, h := range file.Hunks {
+			for _, l := range h.Lines {
+				if l.Kind == "context" || len(matches) == maxPickaxeMatches {
+					continue
+				}
+				col := match(l.Content)
+				if col < 0 {
+					continue
+				}
+				m := PickaxeMatch{GrepHit: GrepHit{Rev: rev, Path: path, Line: l.NewNumber, Column: col + 1, Text: l.Content}}
+				if l.Kind == "delete" {
+					m.Removed = true
+					m.Path = file.OldPath
+					m.Line = l.OldNumber
+				}
+				matches = append(matches, m)
+			}
+		}
+	}
+	return files, matches
+}
+
+// GrepFiles searches file contents in the working tree or at a revision
+// with `git grep`, stopping once the limit is reached. Cancelling ctx stops
+// the search and returns its error.
+func GrepFiles(ctx context.Context, repoPath string, q GrepQuery) (GrepResult, error) {
+	var res GrepResult
+	if err := validateGitRepo(repoPath); err != nil {
+		return res, err
+	}
+	if q.Pattern == "" {
+		return res, errors.New("empty search")
+	}
+	rev := strings.TrimSpace(q.Rev)
+	if strings.HasPrefix(rev, "-") {
+		return res, errors.New("invalid revision")
+	}
+	limit := searchLimit(q.Limit)
+	args := []string{"-C", repoPath, "-c", "core.quotePath=false", "grep", "-z", "-n", "--column", "-I", "--no-color"}
+	if q.Regex {
+		args = append(args, "-E")
+	} else {
+		args = append(args, "-F")
+	}
+	if q.IgnoreCase {
+		args = append(args, "-i")
+	}
+	if q.WholeWord {
+		args = append(args, "-w")
+	}
+	args = append(args, "-e", q.Pattern)
+	if rev != "" {
+		args = append(args, rev)
+	}
+	args = append(args, "--")
+	args = append(args, q.Paths...)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, "git", args...)
+	hideWindow(cmd)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return res, err
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return res, fmt.Errorf("grep failed: %v", err)
+	}
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		hit, ok := parseGrepLine(scanner.Text(), rev)
+		if !ok {
+			continue
+		}
+		if len(res.Hits) == limit {
+			res.Truncated = true
+			cancel()
+			break
+		}
+		res.Hits = append(res.Hits, hit)
+	}
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		return res, ctx.Err()
+	}
+	if res.Truncated {
+		return res, nil
+	}
+	if ee, ok := err.(*exec.ExitError); ok && ee.ExitCode() == 1 && stderr.Len() == 0 {
+		// git grep exits with 1 when nothing matched.
+		return res, nil
+	}
+	if err != nil {
+		return res, fmt.Errorf("grep failed: %v\n%s", err, stderr.String())
+	}
+	return res, nil
+}
+
+// parseGrepLine parses a `git grep -z -n --column` line:
+// "[rev:]path\0line\0column\0text".
+func parseGrepLine(line, rev string) (GrepHit, bool) {
+	f := strings.SplitN(line, "\x00", 4)
+	if len(f) < 4 {
+		return GrepHit{}, false
+	}
+	hit := GrepHit{Rev: rev, Path: f[0], Text: f[3]}
+	if rev != "" {
+		hit.Path = strings.TrimPrefix(hit.Path, rev+":")
+	}
+	var err error
+	if hit.Line, err = strconv.Atoi(f[1]); err != nil {
+		return GrepHit{}, false
+	}
+	hit.Column, _ = strconv.Atoi(f[2])
+	return hit, true
+}
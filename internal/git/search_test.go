@@ -0,0 +1,119 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func setupSearchRepo(t *testing.T) string {
+	t.Helper()
+	dir := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "config.go"), []byte("package config\n\nconst Timeout = 30\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "feat: add timeout setting")
+	if err := os.WriteFile(filepath.Join(dir, "config.go"), []byte("package config\n\nconst Timeout = 60\nconst Retries = 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "-c", "user.name=Other", "-c", "user.email=other@example.com", "commit", "-q", "-am", "fix: raise timeout")
+	return dir
+}
+
+func TestSearchCommits(t *testing.T) {
+	dir := setupSearchRepo(t)
+	ctx := context.Background()
+
+	res, err := SearchCommits(ctx, dir, CommitQuery{Message: "TIMEOUT", IgnoreCase: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Hits) != 2 || res.Truncated {
+		t.Fatalf("unexpected message hits: %+v", res)
+	}
+
+	res, err = SearchCommits(ctx, dir, CommitQuery{Author: "Other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Hits) != 1 || res.Hits[0].Subject != "fix: raise timeout" {
+		t.Errorf("unexpected author hits: %+v", res.Hits)
+	}
+
+	res, err = SearchCommits(ctx, dir, CommitQuery{Pickaxe: "Retries"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Hits) != 1 || len(res.Hits[0].Files) != 1 || res.Hits[0].Files[0] != "config.go" {
+		t.Fatalf("unexpected pickaxe hits: %+v", res.Hits)
+	}
+	if m := res.Hits[0].Matches; len(m) != 1 || m[0].Line != 4 || m[0].Column != 7 || m[0].Text != "const Retries = 3" || m[0].Removed || m[0].Rev != res.Hits[0].Hash {
+		t.Errorf("unexpected pickaxe lines: %+v", m)
+	}
+
+	res, err = SearchCommits(ctx, dir, CommitQuery{PickaxeRegex: `Timeout = [0-9]+`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Hits) != 2 {
+		t.Fatalf("unexpected -G hits: %+v", res.Hits)
+	}
+	// The newest commit replaced "Timeout = 30" on line 3 with "Timeout = 60".
+	if m := res.Hits[0].Matches; len(m) != 2 || !m[0].Removed || m[0].Text != "const Timeout = 30" || m[0].Line != 3 || m[1].Removed || m[1].Text != "const Timeout = 60" {
+		t.Errorf("unexpected -G lines: %+v", m)
+	}
+
+	res, err = SearchCommits(ctx, dir, CommitQuery{Message: "timeout", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Hits) != 1 || !res.Truncated {
+		t.Errorf("expected a truncated result: %+v", res)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := SearchCommits(cancelled, dir, CommitQuery{Message: "timeout"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancellation error, got %v", err)
+	}
+}
+
+func TestGrepFiles(t *testing.T) {
+	dir := setupSearchRepo(t)
+	ctx := context.Background()
+
+	res, err := GrepFiles(ctx, dir, GrepQuery{Pattern: "Timeout"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Hits) != 1 {
+		t.Fatalf("unexpected hits: %+v", res)
+	}
+	if h := res.Hits[0]; h.Path != "config.go" || h.Line != 3 || h.Column != 7 || h.Text != "const Timeout = 60" {
+		t.Errorf("unexpected hit: %+v", h)
+	}
+
+	res, err = GrepFiles(ctx, dir, GrepQuery{Pattern: "= [0-9]+", Regex: true, Rev: "HEAD~1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Hits) != 1 || res.Hits[0].Path != "config.go" || res.Hits[0].Rev != "HEAD~1" || res.Hits[0].Text != "const Timeout = 30" {
+		t.Errorf("unexpected revision hits: %+v", res.Hits)
+	}
+
+	res, err = GrepFiles(ctx, dir, GrepQuery{Pattern: "const", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Hits) != 1 || !res.Truncated {
+		t.Errorf("expected a truncated result: %+v", res)
+	}
+
+	res, err = GrepFiles(ctx, dir, GrepQuery{Pattern: "no such text"})
+	if err != nil || len(res.Hits) != 0 {
+		t.Errorf("expected no hits and no error: %+v %v", res, err)
+	}
+}
@@ -0,0 +1,294 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SemVer is a parsed semantic version.
+type SemVer struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Build      string
+}
+
+var (
+	semverPattern  = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+	channelPattern = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
+)
+
+// ParseSemVer parses tag as a semantic version after removing prefix.
+func ParseSemVer(tag, prefix string) (SemVer, bool) {
+	rest, ok := strings.CutPrefix(tag, prefix)
+	if !ok {
+		return SemVer{}, false
+	}
+	m := semverPattern.FindStringSubmatch(rest)
+	if m == nil {
+		return SemVer{}, false
+	}
+	var v SemVer
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	v.Patch, _ = strconv.Atoi(m[3])
+	v.Prerelease = m[4]
+	v.Build = m[5]
+	return v, true
+}
+
+// String formats v without a prefix.
+func (v SemVer) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 following semver precedence. Build metadata
+// is ignored.
+func (v SemVer) Compare(o SemVer) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(o.Prerelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		an, aErr := strconv.Atoi(a[i])
+		bn, bErr := strconv.Atoi(b[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return sign(an - bn)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// BumpOptions controls PlanVersionBump and ReleaseVersion.
+type BumpOptions struct {
+	// Prefix is prepended to version tags, typically "v". It may be empty.
+	Prefix string
+	// Bump forces "major", "minor" or "patch". Empty derives it from the
+	// Conventional Commit types since the last release.
+	Bump string
+	// Channel creates a prerelease such as "rc" or "beta" (1.2.0-rc.1).
+	Channel string
+	// ReleaseBranch is the branch HEAD must be on. Empty means "main".
+	ReleaseBranch string
+	// Push pushes the new tag to Remote (origin when empty).
+	Push   bool
+	Remote string
+}
+
+// BumpPlan describes the next release computed by PlanVersionBump.
+type BumpPlan struct {
+	// LastRelease is the latest stable release tag, empty if there is none.
+	LastRelease string
+	Bump        string
+	Next        string
+	Commits     int
+	Breaking    int
+	Features    int
+	Fixes       int
+	Created     bool
+	Pushed      bool
+}
+
+// PlanVersionBump computes the next version from the tags reachable from
+// HEAD and the commits made since the last stable release.
+func PlanVersionBump(repoPath string, opts BumpOptions) (BumpPlan, error) {
+	var plan BumpPlan
+	if err := validateGitRepo(repoPath); err != nil {
+		return plan, err
+	}
+	switch opts.Bump {
+	case "", "major", "minor", "patch":
+	default:
+		return plan, fmt.Errorf("unknown bump: %s", opts.Bump)
+	}
+	channel := strings.TrimSpace(opts.Channel)
+	if channel != "" && !channelPattern.MatchString(channel) {
+		return plan, fmt.Errorf("invalid prerelease channel %q", channel)
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "tag", "--merged", "HEAD", "--list")
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return plan, fmt.Errorf("listing tags failed: %v\n%s", err, string(out))
+	}
+	var versions []SemVer
+	var last SemVer
+	for _, tag := range parseTagList(string(out)) {
+		v, ok := ParseSemVer(tag, opts.Prefix)
+		if !ok {
+			continue
+		}
+		versions = append(versions, v)
+		if v.Prerelease == "" && (plan.LastRelease == "" || v.Compare(last) > 0) {
+			last = v
+			plan.LastRelease = tag
+		}
+	}
+
+	entries, err := changelogEntries(repoPath, plan.LastRelease, "HEAD")
+	if err != nil {
+		return plan, err
+	}
+	plan.Commits = len(entries)
+	for _, e := range entries {
+		switch {
+		case e.Breaking:
+			plan.Breaking++
+		case e.Type == "feat":
+			plan.Features++
+		case e.Type == "fix":
+			plan.Fixes++
+		}
+	}
+	if plan.Commits == 0 && opts.Bump == "" {
+		return plan, fmt.Errorf("no commits since %s", plan.LastRelease)
+	}
+
+	plan.Bump = opts.Bump
+	if plan.Bump == "" {
+		plan.Bump = "patch"
+		if plan.Features > 0 {
+			plan.Bump = "minor"
+		}
+		if plan.Breaking > 0 {
+			plan.Bump = "major"
+		}
+	}
+	next := bumpVersion(last, plan.Bump)
+	if plan.LastRelease == "" && opts.Bump == "" {
+		// The first release of a project without version tags.
+		next = SemVer{Minor: 1}
+	}
+	if channel != "" {
+		next.Prerelease = channel + "." + strconv.Itoa(nextPrereleaseNumber(versions, next, channel))
+	}
+	plan.Next = opts.Prefix + next.String()
+	return plan, nil
+}
+
+// bumpVersion increments v by the given part, dropping prerelease and build data.
+func bumpVersion(v SemVer, part string) SemVer {
+	switch part {
+	case "major":
+		return SemVer{Major: v.Major + 1}
+	case "minor":
+		return SemVer{Major: v.Major, Minor: v.Minor + 1}
+	default:
+		return SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+}
+
+// nextPrereleaseNumber returns the number following the highest existing
+// "<channel>.N" prerelease of release, starting at 1.
+func nextPrereleaseNumber(versions []SemVer, release SemVer, channel string) int {
+	n := 0
+	for _, v := range versions {
+		if v.Major != release.Major || v.Minor != release.Minor || v.Patch != release.Patch {
+			continue
+		}
+		rest, ok := strings.CutPrefix(v.Prerelease, channel+".")
+		if !ok {
+			continue
+		}
+		if i, err := strconv.Atoi(rest); err == nil && i > n {
+			n = i
+		}
+	}
+	return n + 1
+}
+
+// ReleaseVersion plans the next version and creates it as an annotated tag
+// whose message holds the release notes, optionally pushing it. It refuses
+// to run with uncommitted changes or when HEAD is not on the release branch.
+func ReleaseVersion(repoPath string, opts BumpOptions) (BumpPlan, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return BumpPlan{}, err
+	}
+	status := exec.Command("git", "-C", repoPath, "status", "--porcelain", "--untracked-files=no")
+	hideWindow(status)
+	out, err := status.Output()
+	if err != nil {
+		return BumpPlan{}, fmt.Errorf("checking worktree failed: %v", err)
+	}
+	if strings.TrimSpace(string(out)) != "" {
+		return BumpPlan{}, errors.New("uncommitted changes present — please commit or stash before releasing")
+	}
+
+	branch := strings.TrimSpace(opts.ReleaseBranch)
+	if branch == "" {
+		branch = "main"
+	}
+	current := exec.Command("git", "-C", repoPath, "branch", "--show-current")
+	hideWindow(current)
+	out, _ = current.Output()
+	switch cur := strings.TrimSpace(string(out)); cur {
+	case branch:
+	case "":
+		return BumpPlan{}, fmt.Errorf("HEAD is detached, releases must be made from %q", branch)
+	default:
+		return BumpPlan{}, fmt.Errorf("HEAD is on %q, releases must be made from %q", cur, branch)
+	}
+
+	plan, err := PlanVersionBump(repoPath, opts)
+	if err != nil {
+		return plan, err
+	}
+	// The notes cover exactly the commits the bump was computed from: those
+	// since the last stable semver tag, or all of them when there is none.
+	notes := ChangelogOptions{From: plan.LastRelease, FullHistory: plan.LastRelease == "", Version: plan.Next, Tag: true}
+	if _, err := GenerateChangelog(repoPath, notes); err != nil {
+		return plan, err
+	}
+	plan.Created = true
+	if opts.Push {
+		if _, err := PushTag(repoPath, opts.Remote, plan.Next); err != nil {
+			return plan, err
+		}
+		plan.Pushed = true
+	}
+	return plan, nil
+}
@@ -0,0 +1,111 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSemVer(t *testing.T) {
+	v, ok := ParseSemVer("v1.2.3-rc.1+build.5", "v")
+	if !ok || v.Major != 1 || v.Minor != 2 || v.Patch != 3 || v.Prerelease != "rc.1" || v.Build != "build.5" {
+		t.Errorf("unexpected version: %+v, %v", v, ok)
+	}
+	if v.String() != "1.2.3-rc.1+build.5" {
+		t.Errorf("unexpected string: %s", v.String())
+	}
+	for _, bad := range []string{"1.2.3", "v1.2", "v01.2.3", "release-1"} {
+		if _, ok := ParseSemVer(bad, "v"); ok {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestSemVerCompare(t *testing.T) {
+	order := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+	for i := 0; i+1 < len(order); i++ {
+		a, _ := ParseSemVer(order[i], "")
+		b, _ := ParseSemVer(order[i+1], "")
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", order[i], order[i+1])
+		}
+	}
+}
+
+func TestNextPrereleaseNumber(t *testing.T) {
+	var versions []SemVer
+	for _, s := range []string{"1.1.0-rc.1", "1.1.0-rc.2", "1.1.0-beta.7", "1.0.0"} {
+		v, _ := ParseSemVer(s, "")
+		versions = append(versions, v)
+	}
+	release := SemVer{Major: 1, Minor: 1}
+	if n := nextPrereleaseNumber(versions, release, "rc"); n != 3 {
+		t.Errorf("expected rc.3, got %d", n)
+	}
+	if n := nextPrereleaseNumber(versions, SemVer{Major: 2}, "rc"); n != 1 {
+		t.Errorf("expected rc.1, got %d", n)
+	}
+}
+
+func TestPlanAndReleaseVersion(t *testing.T) {
+	dir := initTestRepo(t)
+	gitRun(t, dir, "tag", "v1.0.0")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "fix: patch things")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "feat: new thing")
+
+	plan, err := PlanVersionBump(dir, BumpOptions{Prefix: "v"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.LastRelease != "v1.0.0" || plan.Bump != "minor" || plan.Next != "v1.1.0" || plan.Commits != 2 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+
+	if _, err := ReleaseVersion(dir, BumpOptions{Prefix: "v", ReleaseBranch: "release"}); err == nil {
+		t.Error("expected release from the wrong branch to be refused")
+	}
+	gitRun(t, dir, "switch", "-q", "--detach")
+	if _, err := ReleaseVersion(dir, BumpOptions{Prefix: "v"}); err == nil || !strings.Contains(err.Error(), "HEAD is detached") {
+		t.Errorf("expected detached HEAD to be reported, got %v", err)
+	}
+	gitRun(t, dir, "switch", "-q", "main")
+	plan, err = ReleaseVersion(dir, BumpOptions{Prefix: "v", Channel: "rc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Next != "v1.1.0-rc.1" || !plan.Created {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "feat!: breaking thing")
+	plan, err = PlanVersionBump(dir, BumpOptions{Prefix: "v", Channel: "rc"})
+	if err != nil || plan.Next != "v2.0.0-rc.1" {
+		t.Errorf("unexpected plan: %+v, %v", plan, err)
+	}
+}
+
+func TestReleaseNotesMatchBumpRange(t *testing.T) {
+	dir := initTestRepo(t)
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "feat: first feature")
+	gitRun(t, dir, "tag", "nightly")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "fix: first fix")
+
+	plan, err := ReleaseVersion(dir, BumpOptions{Prefix: "v"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes := gitRun(t, dir, "tag", "-l", "--format=%(contents)", plan.Next)
+	if plan.Next != "v0.1.0" || !strings.Contains(notes, "first feature") || !strings.Contains(notes, "first fix") {
+		t.Errorf("expected notes for the whole history in %s:\n%s", plan.Next, notes)
+	}
+
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "feat: second feature")
+	gitRun(t, dir, "tag", "v0.2.0-rc.1")
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "fix: second fix")
+	plan, err = ReleaseVersion(dir, BumpOptions{Prefix: "v"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes = gitRun(t, dir, "tag", "-l", "--format=%(contents)", plan.Next)
+	if plan.Next != "v0.2.0" || !strings.Contains(notes, "second feature") || strings.Contains(notes, "first fix") {
+		t.Errorf("expected notes since v0.1.0 in %s:\n%s", plan.Next, notes)
+	}
+}
@@ -0,0 +1,240 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// PickOptions controls CherryPickCommits and RevertCommits.
+type PickOptions struct {
+	// RecordOrigin appends "(cherry picked from commit ...)" to messages (-x).
+	// It only applies to cherry-picks.
+	RecordOrigin bool
+	// Mainline selects the parent to diff against when picking or reverting
+	// merge commits (--mainline). Zero leaves it unset.
+	Mainline int
+	// NoCommit applies the changes to the index and worktree without committing.
+	NoCommit bool
+}
+
+// SequencerResult reports the outcome of a cherry-pick or revert.
+type SequencerResult struct {
+	Output     string
+	InProgress bool
+	Conflicts  []string
+}
+
+// CherryPickCommits applies commits in order. Each entry may be a single
+// commit or a range such as "A..B". When a conflict stops the sequence the
+// result lists the conflicting files and SequencerControl can continue,
+// skip or abort it. The worktree must be clean.
+func CherryPickCommits(repoPath string, commits []string, opts PickOptions) (SequencerResult, error) {
+	if err := requireCleanWorktree(repoPath, "cherry-picking"); err != nil {
+		return SequencerResult{}, err
+	}
+	return runSequencer(repoPath, "cherry-pick", commits, opts)
+}
+
+// RevertCommits reverts commits in order. Each entry may be a single commit
+// or a range such as "A..B". The worktree must be clean.
+func RevertCommits(repoPath string, commits []string, opts PickOptions) (SequencerResult, error) {
+	if err := requireCleanWorktree(repoPath, "reverting"); err != nil {
+		return SequencerResult{}, err
+	}
+	if opts.RecordOrigin {
+		return SequencerResult{}, errors.New("-x only applies to cherry-pick")
+	}
+	return runSequencer(repoPath, "revert", commits, opts)
+}
+
+// requireCleanWorktree fails when tracked files have uncommitted changes.
+func requireCleanWorktree(repoPath, action string) error {
+	if err := validateGitRepo(repoPath); err != nil {
+		return err
+	}
+	checkChanges := exec.Command("git", "-C", repoPath, "status", "--porcelain", "--untracked-files=no")
+	hideWindow(checkChanges)
+	out, _ := checkChanges.Output()
+	if strings.TrimSpace(string(out)) != "" {
+		return errors.New("uncommitted changes present — please commit or stash before " + action)
+	}
+	return nil
+}
+
+// sequencerCommits expands ranges in commits to the commits they contain,
+// in the order operation applies them, so that the list passed to git holds
+// only single commits. git walks every argument as one rev-list as soon as
+// any of them is a range, so a mix of "A..B" and "C" would otherwise also
+// pick everything reachable from C.
+func sequencerCommits(repoPath, operation string, commits []string) ([]string, error) {
+	var expanded []string
+	for _, c := range commits {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		if strings.HasPrefix(c, "-") {
+			return nil, fmt.Errorf("invalid commit %q", c)
+		}
+		if !strings.Contains(c, "..") {
+			expanded = append(expanded, c)
+			continue
+		}
+		args := []string{"-C", repoPath, "rev-list"}
+		if operation == "cherry-pick" {
+			// Oldest first; revert undoes the newest commit first.
+			args = append(args, "--reverse")
+		}
+		cmd := exec.Command("git", append(args, c, "--")...)
+		hideWindow(cmd)
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("invalid range %q: %v", c, err)
+		}
+		hashes := strings.Fields(string(out))
+		if len(hashes) == 0 {
+			return nil, fmt.Errorf("range %s contains no commits", c)
+		}
+		expanded = append(expanded, hashes...)
+	}
+	if len(expanded) == 0 {
+		return nil, errors.New("no commits selected")
+	}
+	return expanded, nil
+}
+
+func runSequencer(repoPath, operation string, commits []string, opts PickOptions) (SequencerResult, error) {
+	var result SequencerResult
+	if err := validateGitRepo(repoPath); err != nil {
+		return result, err
+	}
+	if len(commits) == 0 {
+		return result, errors.New("no commits selected")
+	}
+	if opts.Mainline < 0 {
+		return result, errors.New("mainline parent number cannot be negative")
+	}
+
+	args := []string{"-C", repoPath, operation}
+	if opts.RecordOrigin {
+		args = append(args, "-x")
+	}
+	if opts.Mainline > 0 {
+		args = append(args, "--mainline", strconv.Itoa(opts.Mainline))
+	}
+	if opts.NoCommit {
+		args = append(args, "--no-commit")
+	} else if operation == "revert" {
+		args = append(args, "--no-edit")
+	}
+	expanded, err := sequencerCommits(repoPath, operation, commits)
+	if err != nil {
+		return result, err
+	}
+	args = append(args, expanded...)
+
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	result.Output = string(out)
+	if err != nil {
+		result.InProgress = SequencerInProgress(repoPath) == operation
+		result.Conflicts = conflictedFiles(repoPath)
+		return result, fmt.Errorf("%s failed: %v\n%s", operation, err, string(out))
+	}
+	return result, nil
+}
+
+// SequencerControl continues, skips, aborts or quits an in-progress
+// cherry-pick or revert. operation is "cherry-pick" or "revert" and action
+// is one of "continue", "skip", "abort" or "quit".
+func SequencerControl(repoPath, operation, action string) (SequencerResult, error) {
+	var result SequencerResult
+	if err := validateGitRepo(repoPath); err != nil {
+		return result, err
+	}
+	if operation != "cherry-pick" && operation != "revert" {
+		return result, fmt.Errorf("unknown operation: %s", operation)
+	}
+	switch action {
+	case "continue", "skip", "abort", "quit":
+	default:
+		return result, fmt.Errorf("unknown sequencer action: %s", action)
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, operation, "--"+action)
+	// Accept the prepared message instead of waiting for an editor.
+	cmd.Env = append(os.Environ(), "GIT_EDITOR=true")
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	result.Output = string(out)
+	result.InProgress = SequencerInProgress(repoPath) == operation
+	if err != nil {
+		result.Conflicts = conflictedFiles(repoPath)
+		return result, fmt.Errorf("%s --%s failed: %v\n%s", operation, action, err, string(out))
+	}
+	return result, nil
+}
+
+// SequencerInProgress reports which sequencer operation ("cherry-pick" or
+// "revert") is stopped in repoPath, or "" when none is.
+func SequencerInProgress(repoPath string) string {
+	if gitPathExists(repoPath, "CHERRY_PICK_HEAD") {
+		return "cherry-pick"
+	}
+	if gitPathExists(repoPath, "REVERT_HEAD") {
+		return "revert"
+	}
+	// A multi-commit sequence that stopped on an empty commit or was
+	// resolved with --no-commit leaves only the sequencer directory behind.
+	if gitPathExists(repoPath, "sequencer/todo") {
+		todo, err := os.ReadFile(gitPath(repoPath, "sequencer/todo"))
+		if err == nil && strings.HasPrefix(strings.TrimSpace(string(todo)), "revert") {
+			return "revert"
+		}
+		return "cherry-pick"
+	}
+	return ""
+}
+
+// gitPath resolves name inside the repository's git directory.
+func gitPath(repoPath, name string) string {
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--git-path", name)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return filepath.Join(repoPath, ".git", name)
+	}
+	p := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(repoPath, p)
+	}
+	return p
+}
+
+func gitPathExists(repoPath, name string) bool {
+	_, err := os.Stat(gitPath(repoPath, name))
+	return err == nil
+}
+
+// conflictedFiles lists unmerged paths in repoPath.
+func conflictedFiles(repoPath string) []string {
+	cmd := exec.Command("git", "-C", repoPath, "diff", "--name-only", "--diff-filter=U")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, l := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if l != "" {
+			files = append(files, l)
+		}
+	}
+	return files
+}
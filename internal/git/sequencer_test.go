@@ -0,0 +1,101 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCherryPickRangeAndConflict(t *testing.T) {
+	dir := initTestRepo(t)
+	base := strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD"))
+
+	gitRun(t, dir, "switch", "-c", "topic")
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		gitRun(t, dir, "add", name)
+		gitRun(t, dir, "commit", "-m", "add "+name)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("topic\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "commit", "-am", "topic readme")
+	gitRun(t, dir, "switch", "main")
+
+	if _, err := CherryPickCommits(dir, []string{base + "..topic~1"}, PickOptions{RecordOrigin: true}); err != nil {
+		t.Fatal(err)
+	}
+	if msg := gitRun(t, dir, "log", "-1", "--format=%B"); !strings.Contains(msg, "cherry picked from commit") {
+		t.Errorf("expected -x provenance, got %q", msg)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "commit", "-am", "main readme")
+
+	result, err := CherryPickCommits(dir, []string{"topic"}, PickOptions{})
+	if err == nil {
+		t.Fatal("expected conflict")
+	}
+	if !result.InProgress || len(result.Conflicts) != 1 || result.Conflicts[0] != "README.md" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if op := SequencerInProgress(dir); op != "cherry-pick" {
+		t.Errorf("expected cherry-pick in progress, got %q", op)
+	}
+	if _, err := SequencerControl(dir, "cherry-pick", "abort"); err != nil {
+		t.Fatal(err)
+	}
+	if op := SequencerInProgress(dir); op != "" {
+		t.Errorf("expected no operation in progress, got %q", op)
+	}
+}
+
+func TestRevertCommitsRejectsRecordOrigin(t *testing.T) {
+	dir := initTestRepo(t)
+	if _, err := RevertCommits(dir, []string{"HEAD"}, PickOptions{RecordOrigin: true}); err == nil {
+		t.Error("expected -x to be rejected for revert")
+	}
+	if _, err := SequencerControl(dir, "revert", "rewind"); err == nil {
+		t.Error("expected unknown action to be rejected")
+	}
+}
+
+func TestCherryPickMixedRangeAndCommit(t *testing.T) {
+	dir := initTestRepo(t)
+	gitRun(t, dir, "switch", "-c", "topic")
+	var hashes []string
+	for _, name := range []string{"s1.txt", "s2.txt", "s3.txt", "s4.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		gitRun(t, dir, "add", name)
+		gitRun(t, dir, "commit", "-m", "add "+name)
+		hashes = append(hashes, strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD")))
+	}
+	gitRun(t, dir, "switch", "main")
+
+	if _, err := CherryPickCommits(dir, []string{hashes[0] + ".." + hashes[1], hashes[3]}, PickOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	subjects := strings.Fields(gitRun(t, dir, "log", "--format=%s", "-2"))
+	if len(subjects) != 4 || subjects[1] != "s4.txt" || subjects[3] != "s2.txt" {
+		t.Errorf("expected s2 then s4 to be picked, got %q", subjects)
+	}
+	for _, name := range []string{"s1.txt", "s3.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s should not have been picked", name)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("dirty\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CherryPickCommits(dir, []string{hashes[2]}, PickOptions{}); err == nil {
+		t.Error("expected uncommitted changes to block the cherry-pick")
+	}
+}
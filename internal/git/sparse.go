@@ -0,0 +1,269 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// SparseStatus describes the sparse-checkout state of a repository.
+type SparseStatus struct {
+	Enabled bool
+	Cone    bool
+	// Directories lists the checked-out directories in cone mode. Patterns
+	// holds the raw sparse-checkout patterns in non-cone mode instead.
+	Directories []string
+	Patterns    []string
+	// TrackedFiles counts index entries; PresentFiles those checked out,
+	// i.e. without the skip-worktree bit.
+	TrackedFiles int
+	PresentFiles int
+}
+
+// SparseDirectory is a directory that can be added to a cone-mode sparse
+// checkout, with the cost of checking it out.
+type SparseDirectory struct {
+	Path string
+	// Included is set when the directory is checked out in full; Partial
+	// when only some of its subdirectories are.
+	Included bool
+	Partial  bool
+	Files    int
+	Size     int64
+}
+
+// GetSparseCheckout returns whether sparse-checkout is enabled, the
+// checked-out directories and how many tracked files are present.
+func GetSparseCheckout(repoPath string) (SparseStatus, error) {
+	var st SparseStatus
+	if err := validateGitRepo(repoPath); err != nil {
+		return st, err
+	}
+	st.Enabled = gitConfigBool(repoPath, "core.sparseCheckout")
+	st.Cone = st.Enabled && gitConfigBool(repoPath, "core.sparseCheckoutCone")
+	if st.Enabled {
+		cmd := exec.Command("git", "-C", repoPath, "sparse-checkout", "list")
+		hideWindow(cmd)
+		out, err := cmd.Output()
+		if err != nil {
+			return st, fmt.Errorf("sparse-checkout list failed: %v", err)
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			if line = strings.TrimSpace(line); line == "" {
+				continue
+			}
+			if st.Cone {
+				st.Directories = append(st.Directories, line)
+			} else {
+				st.Patterns = append(st.Patterns, line)
+			}
+		}
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "ls-files", "-t", "-z")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return st, fmt.Errorf("ls-files failed: %v", err)
+	}
+	for _, rec := range strings.Split(string(out), "\x00") {
+		if rec == "" {
+			continue
+		}
+		st.TrackedFiles++
+		if !strings.HasPrefix(rec, "S ") {
+			st.PresentFiles++
+		}
+	}
+	return st, nil
+}
+
+// gitConfigBool reads a boolean config value, treating unset as false.
+func gitConfigBool(repoPath, key string) bool {
+	cmd := exec.Command("git", "-C", repoPath, "config", "--bool", "--get", key)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// cleanSparseDirs normalizes directory arguments and rejects ones that
+// escape the repository or look like flags.
+func cleanSparseDirs(dirs []string) ([]string, error) {
+	var cleaned []string
+	for _, d := range dirs {
+		d = strings.Trim(path.Clean(strings.ReplaceAll(strings.TrimSpace(d), `\`, "/")), "/")
+		if d == "" || d == "." || d == ".." || strings.HasPrefix(d, "../") || strings.HasPrefix(d, "-") {
+			return nil, fmt.Errorf("invalid directory %q", d)
+		}
+		cleaned = append(cleaned, d)
+	}
+	return cleaned, nil
+}
+
+func runSparseCheckout(repoPath string, args ...string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	cmd := exec.Command("git", append([]string{"-C", repoPath, "sparse-checkout"}, args...)...)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("sparse-checkout %s failed: %v\n%s", args[0], err, string(out))
+	}
+	return string(out), nil
+}
+
+// EnableSparseCheckout turns on cone-mode sparse-checkout with only dirs
+// (and the files at the repository root) checked out.
+func EnableSparseCheckout(repoPath string, dirs []string) (string, error) {
+	dirs, err := cleanSparseDirs(dirs)
+	if err != nil {
+		return "", err
+	}
+	return runSparseCheckout(repoPath, append([]string{"set", "--cone", "--"}, dirs...)...)
+}
+
+// AddSparseDirectories checks out dirs in addition to the current ones.
+func AddSparseDirectories(repoPath string, dirs []string) (string, error) {
+	dirs, err := cleanSparseDirs(dirs)
+	if err != nil {
+		return "", err
+	}
+	if len(dirs) == 0 {
+		return "", errors.New("no directories to add")
+	}
+	if !gitConfigBool(repoPath, "core.sparseCheckout") {
+		return "", errors.New("sparse-checkout is not enabled")
+	}
+	return runSparseCheckout(repoPath, append([]string{"add", "--"}, dirs...)...)
+}
+
+// RemoveSparseDirectories stops checking out dirs. git has no remove
+// command, so the remaining directories are set again.
+func RemoveSparseDirectories(repoPath string, dirs []string) (string, error) {
+	dirs, err := cleanSparseDirs(dirs)
+	if err != nil {
+		return "", err
+	}
+	st, err := GetSparseCheckout(repoPath)
+	if err != nil {
+		return "", err
+	}
+	if !st.Cone {
+		return "", errors.New("removing directories requires cone-mode sparse-checkout")
+	}
+	drop := make(map[string]bool)
+	for _, d := range dirs {
+		drop[d] = true
+	}
+	var keep []string
+	for _, d := range st.Directories {
+		if !drop[d] {
+			keep = append(keep, d)
+		}
+	}
+	if len(keep) == len(st.Directories) {
+		return "", fmt.Errorf("none of %s are checked out", strings.Join(dirs, ", "))
+	}
+	return runSparseCheckout(repoPath, append([]string{"set", "--cone", "--"}, keep...)...)
+}
+
+// DisableSparseCheckout checks out every file again.
+func DisableSparseCheckout(repoPath string) (string, error) {
+	return runSparseCheckout(repoPath, "disable")
+}
+
+// ListSparseDirectories lists the subdirectories of parent at HEAD, using
+// `git ls-tree -d`, with their file count and total size and whether the
+// current sparse-checkout includes them. Inclusion is only known in cone
+// mode; with non-cone patterns no directory is marked.
+func ListSparseDirectories(repoPath, parent string) ([]SparseDirectory, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	parent, err := cleanTreePath(parent)
+	if err != nil {
+		return nil, err
+	}
+	prefix := ""
+	if parent != "" {
+		prefix = parent + "/"
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "ls-tree", "-d", "-z", "HEAD", "--", prefix)
+	if parent == "" {
+		cmd = exec.Command("git", "-C", repoPath, "ls-tree", "-d", "-z", "HEAD")
+	}
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing directories failed: %v", err)
+	}
+	dirs := []SparseDirectory{}
+	index := make(map[string]int)
+	for _, rec := range strings.Split(string(out), "\x00") {
+		meta, name, ok := strings.Cut(rec, "\t")
+		if !ok || !strings.Contains(meta, " tree ") {
+			continue
+		}
+		index[name] = len(dirs)
+		dirs = append(dirs, SparseDirectory{Path: name})
+	}
+	if len(dirs) == 0 {
+		return dirs, nil
+	}
+
+	args := []string{"-C", repoPath, "ls-tree", "-r", "-l", "-z", "HEAD"}
+	if parent != "" {
+		args = append(args, "--", prefix)
+	}
+	sizes := exec.Command("git", args...)
+	hideWindow(sizes)
+	out, err = sizes.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing files failed: %v", err)
+	}
+	for _, rec := range strings.Split(string(out), "\x00") {
+		meta, name, ok := strings.Cut(rec, "\t")
+		f := strings.Fields(meta)
+		if !ok || len(f) < 4 || f[1] != "blob" {
+			continue
+		}
+		rest := strings.TrimPrefix(name, prefix)
+		top, _, nested := strings.Cut(rest, "/")
+		if !nested {
+			continue
+		}
+		if i, ok := index[prefix+top]; ok {
+			size, _ := strconv.ParseInt(f[3], 10, 64)
+			dirs[i].Files++
+			dirs[i].Size += size
+		}
+	}
+
+	st, err := GetSparseCheckout(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	for i := range dirs {
+		if !st.Enabled {
+			dirs[i].Included = true
+			continue
+		}
+		for _, d := range st.Directories {
+			switch {
+			case dirs[i].Path == d || strings.HasPrefix(dirs[i].Path, d+"/"):
+				dirs[i].Included = true
+			case strings.HasPrefix(d, dirs[i].Path+"/"):
+				dirs[i].Partial = true
+			}
+		}
+		if dirs[i].Included {
+			dirs[i].Partial = false
+		}
+	}
+	return dirs, nil
+}
@@ -0,0 +1,107 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSparseCheckout(t *testing.T) {
+	dir := initTestRepo(t)
+	for name, content := range map[string]string{
+		"services/api/main.go":  "package main\n",
+		"services/web/app.js":   "app()\n",
+		"docs/guide.md":         "guide\n",
+		"docs/images/logo.svg":  "<svg/>\n",
+		"tools/build/script.sh": "echo build\n",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "monorepo")
+
+	dirs, err := ListSparseDirectories(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirs) != 3 || dirs[0].Path != "docs" || dirs[0].Files != 2 || dirs[0].Size != 13 || !dirs[0].Included {
+		t.Fatalf("unexpected directories: %+v", dirs)
+	}
+
+	if _, err := EnableSparseCheckout(dir, []string{"services/api"}); err != nil {
+		t.Fatal(err)
+	}
+	st, err := GetSparseCheckout(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.Enabled || !st.Cone || len(st.Directories) != 1 || st.Directories[0] != "services/api" {
+		t.Errorf("unexpected status: %+v", st)
+	}
+	if st.TrackedFiles != 6 || st.PresentFiles != 2 {
+		t.Errorf("expected README and main.go present: %+v", st)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "docs")); !os.IsNotExist(err) {
+		t.Errorf("docs should not be checked out: %v", err)
+	}
+
+	dirs, err = ListSparseDirectories(dir, "services")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirs) != 2 || dirs[0].Path != "services/api" || !dirs[0].Included || dirs[1].Included {
+		t.Errorf("unexpected service directories: %+v", dirs)
+	}
+	root, _ := ListSparseDirectories(dir, "")
+	if root[1].Path != "services" || !root[1].Partial || root[1].Included {
+		t.Errorf("expected services to be partially included: %+v", root[1])
+	}
+
+	if _, err := AddSparseDirectories(dir, []string{"docs"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RemoveSparseDirectories(dir, []string{"services/api"}); err != nil {
+		t.Fatal(err)
+	}
+	st, _ = GetSparseCheckout(dir)
+	if len(st.Directories) != 1 || st.Directories[0] != "docs" {
+		t.Errorf("unexpected directories after add/remove: %v", st.Directories)
+	}
+	out, err := LsFiles(dir, "Sparse (-t)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "S services/api/main.go") || !strings.Contains(out, "H docs/guide.md") {
+		t.Errorf("unexpected ls-files output:\n%s", out)
+	}
+
+	if _, err := DisableSparseCheckout(dir); err != nil {
+		t.Fatal(err)
+	}
+	st, _ = GetSparseCheckout(dir)
+	if st.Enabled || st.PresentFiles != st.TrackedFiles {
+		t.Errorf("expected a full checkout: %+v", st)
+	}
+	if _, err := EnableSparseCheckout(dir, []string{"../outside"}); err == nil {
+		t.Error("expected directories outside the repository to be rejected")
+	}
+}
+
+func TestSparseCheckoutPatterns(t *testing.T) {
+	dir := initTestRepo(t)
+	gitRun(t, dir, "sparse-checkout", "set", "--no-cone", "/*.md")
+	st, err := GetSparseCheckout(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.Enabled || st.Cone || len(st.Directories) != 0 || len(st.Patterns) != 1 || st.Patterns[0] != "/*.md" {
+		t.Errorf("expected non-cone patterns: %+v", st)
+	}
+}
@@ -0,0 +1,193 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StashEntry describes one entry of the stash list.
+type StashEntry struct {
+	Index   int
+	Ref     string
+	Hash    string
+	Branch  string
+	Message string
+	Date    time.Time
+}
+
+// StashOptions controls how StashSave creates a stash.
+type StashOptions struct {
+	Message          string
+	IncludeUntracked bool
+	KeepIndex        bool
+	// Paths limits the stash to the given pathspecs. Empty stashes everything.
+	Paths []string
+}
+
+// ListStashes returns the stash list as typed entries, newest first.
+func ListStashes(repoPath string) ([]StashEntry, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("git", "-C", repoPath, "stash", "list", "--format=%gd%x00%H%x00%ct%x00%gs")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing stashes failed: %v", err)
+	}
+	return parseStashList(string(out)), nil
+}
+
+// parseStashList converts `git stash list` output using the ListStashes format.
+func parseStashList(out string) []StashEntry {
+	var entries []StashEntry
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		entry := StashEntry{Ref: fields[0], Hash: fields[1]}
+		idx := strings.TrimSuffix(strings.TrimPrefix(fields[0], "stash@{"), "}")
+		n, err := strconv.Atoi(idx)
+		if err != nil {
+			continue
+		}
+		entry.Index = n
+		if secs, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			entry.Date = time.Unix(secs, 0)
+		}
+		entry.Branch, entry.Message = parseStashSubject(fields[3])
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// parseStashSubject splits reflog subjects like "WIP on main: abc123 msg" or
+// "On main: my message" into the branch name and message.
+func parseStashSubject(subject string) (branch, message string) {
+	rest, ok := strings.CutPrefix(subject, "WIP on ")
+	if !ok {
+		rest, ok = strings.CutPrefix(subject, "On ")
+	}
+	if !ok {
+		return "", subject
+	}
+	branch, message, found := strings.Cut(rest, ": ")
+	if !found {
+		return "", subject
+	}
+	return branch, message
+}
+
+// stashRef returns the stash@{n} reference for index.
+func stashRef(index int) (string, error) {
+	if index < 0 {
+		return "", errors.New("stash index cannot be negative")
+	}
+	return fmt.Sprintf("stash@{%d}", index), nil
+}
+
+// StashSave stashes local changes according to opts.
+func StashSave(repoPath string, opts StashOptions) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	args := []string{"-C", repoPath, "stash", "push"}
+	if msg := strings.TrimSpace(opts.Message); msg != "" {
+		args = append(args, "-m", msg)
+	}
+	if opts.IncludeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if opts.KeepIndex {
+		args = append(args, "--keep-index")
+	}
+	if len(opts.Paths) > 0 {
+		args = append(args, "--")
+		args = append(args, opts.Paths...)
+	}
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("stash save failed: %v\n%s", err, string(out))
+	}
+	return string(out), nil
+}
+
+// StashShow returns the patch recorded in the stash at index.
+func StashShow(repoPath string, index int) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	ref, err := stashRef(index)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command("git", "-C", repoPath, "stash", "show", "-p", ref)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("stash show failed: %v\n%s", err, string(out))
+	}
+	return string(out), nil
+}
+
+// StashApply applies the stash at index, keeping it in the stash list.
+func StashApply(repoPath string, index int) (string, error) {
+	return stashAction(repoPath, "apply", index)
+}
+
+// StashPop applies the stash at index and removes it from the stash list.
+func StashPop(repoPath string, index int) (string, error) {
+	return stashAction(repoPath, "pop", index)
+}
+
+// StashDrop removes the stash at index without applying it.
+func StashDrop(repoPath string, index int) (string, error) {
+	return stashAction(repoPath, "drop", index)
+}
+
+func stashAction(repoPath, action string, index int) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	ref, err := stashRef(index)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command("git", "-C", repoPath, "stash", action, ref)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("stash %s failed: %v\n%s", action, err, string(out))
+	}
+	return string(out), nil
+}
+
+// StashBranch creates branch from the commit the stash at index was based
+// on, checks it out and pops the stash onto it.
+func StashBranch(repoPath, branch string, index int) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	branch = strings.TrimSpace(branch)
+	if err := ValidateBranchName(repoPath, branch); err != nil {
+		return "", err
+	}
+	ref, err := stashRef(index)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command("git", "-C", repoPath, "stash", "branch", branch, ref)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("stash branch failed: %v\n%s", err, string(out))
+	}
+	return string(out), nil
+}
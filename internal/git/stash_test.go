@@ -0,0 +1,64 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseStashSubject(t *testing.T) {
+	cases := []struct{ in, branch, msg string }{
+		{"WIP on main: abc1234 fix bug", "main", "abc1234 fix bug"},
+		{"On feature/x: half-done refactor", "feature/x", "half-done refactor"},
+		{"something else", "", "something else"},
+	}
+	for _, c := range cases {
+		branch, msg := parseStashSubject(c.in)
+		if branch != c.branch || msg != c.msg {
+			t.Errorf("parseStashSubject(%q) = %q, %q; want %q, %q", c.in, branch, msg, c.branch, c.msg)
+		}
+	}
+}
+
+func TestStashLifecycle(t *testing.T) {
+	dir := initTestRepo(t)
+	readme := filepath.Join(dir, "README.md")
+	if err := os.WriteFile(readme, []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := StashSave(dir, StashOptions{Message: "my work", IncludeUntracked: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ListStashes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Index != 0 || entries[0].Branch != "main" || entries[0].Message != "my work" {
+		t.Fatalf("unexpected stash list: %+v", entries)
+	}
+
+	diff, err := StashShow(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == "" {
+		t.Error("expected stash diff to be non-empty")
+	}
+
+	if _, err := StashBranch(dir, "from-stash", 0); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(readme); string(content) != "changed\n" {
+		t.Errorf("expected stashed change to be restored, got %q", content)
+	}
+	if entries, _ := ListStashes(dir); len(entries) != 0 {
+		t.Errorf("expected stash to be consumed, got %+v", entries)
+	}
+	if _, err := StashDrop(dir, -1); err == nil {
+		t.Error("expected negative index to be rejected")
+	}
+}
@@ -0,0 +1,328 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// SubmoduleInfo describes a submodule declared in .gitmodules.
+type SubmoduleInfo struct {
+	Name   string
+	Path   string
+	URL    string
+	Branch string
+	// RecordedCommit is the commit the superproject's index points to;
+	// CheckedOutCommit is the submodule's HEAD, empty if not checked out.
+	RecordedCommit   string
+	CheckedOutCommit string
+	// Initialized is set once the URL has been copied to .git/config
+	// (`git submodule init`).
+	Initialized bool
+	CheckedOut  bool
+	// NewCommits is set when the checked-out commit differs from the
+	// recorded one.
+	NewCommits bool
+	// ModifiedContent and UntrackedContent report uncommitted changes
+	// inside the submodule's working tree.
+	ModifiedContent  bool
+	UntrackedContent bool
+}
+
+// Dirty reports whether the submodule differs from what the superproject records.
+func (s SubmoduleInfo) Dirty() bool {
+	return s.NewCommits || s.ModifiedContent || s.UntrackedContent
+}
+
+// SubmoduleUpdateOptions controls UpdateSubmodules.
+type SubmoduleUpdateOptions struct {
+	// Init initializes submodules that are not yet initialized.
+	Init bool
+	// Remote updates to the tip of each submodule's remote-tracking branch
+	// instead of the recorded commit.
+	Remote    bool
+	Recursive bool
+	// Paths limits the update; empty updates every submodule.
+	Paths []string
+}
+
+// ListSubmodules returns the submodules declared in .gitmodules with their
+// recorded and checked-out commits and working tree state.
+func ListSubmodules(repoPath string) ([]SubmoduleInfo, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	subs := []SubmoduleInfo{}
+	if _, err := os.Stat(filepath.Join(repoPath, ".gitmodules")); err != nil {
+		return subs, nil
+	}
+	cmd := exec.Command("git", "-C", repoPath, "config", "-f", ".gitmodules", "-z", "--get-regexp", `^submodule\.`)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return subs, nil
+	}
+	subs = parseGitmodules(string(out))
+
+	states, err := submoduleStates(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		s := &subs[i]
+		s.RecordedCommit = recordedSubmoduleCommit(repoPath, s.Path)
+
+		initialized := exec.Command("git", "-C", repoPath, "config", "--get", "submodule."+s.Name+".url")
+		hideWindow(initialized)
+		s.Initialized = initialized.Run() == nil
+
+		subDir := filepath.Join(repoPath, filepath.FromSlash(s.Path))
+		if _, err := os.Stat(filepath.Join(subDir, ".git")); err == nil {
+			head := exec.Command("git", "-C", subDir, "rev-parse", "HEAD")
+			hideWindow(head)
+			if out, err := head.Output(); err == nil {
+				s.CheckedOut = true
+				s.CheckedOutCommit = strings.TrimSpace(string(out))
+			}
+		}
+		if st, ok := states[s.Path]; ok {
+			s.NewCommits = st[0] == 'C'
+			s.ModifiedContent = st[1] == 'M'
+			s.UntrackedContent = st[2] == 'U'
+		}
+	}
+	return subs, nil
+}
+
+// parseGitmodules parses `git config -f .gitmodules -z --get-regexp` output,
+// whose records are "submodule.<name>.<key>\n<value>\0". Names may contain dots.
+func parseGitmodules(out string) []SubmoduleInfo {
+	var subs []SubmoduleInfo
+	index := make(map[string]int)
+	for _, rec := range strings.Split(out, "\x00") {
+		key, value, _ := strings.Cut(rec, "\n")
+		rest, ok := strings.CutPrefix(key, "submodule.")
+		if !ok {
+			continue
+		}
+		dot := strings.LastIndex(rest, ".")
+		if dot < 0 {
+			continue
+		}
+		name, field := rest[:dot], rest[dot+1:]
+		i, ok := index[name]
+		if !ok {
+			i = len(subs)
+			index[name] = i
+			subs = append(subs, SubmoduleInfo{Name: name})
+		}
+		switch field {
+		case "path":
+			subs[i].Path = value
+		case "url":
+			subs[i].URL = value
+		case "branch":
+			subs[i].Branch = value
+		}
+	}
+	return subs
+}
+
+// recordedSubmoduleCommit returns the gitlink commit staged for path.
+func recordedSubmoduleCommit(repoPath, path string) string {
+	cmd := exec.Command("git", "-C", repoPath, "ls-files", "-s", "-z", "--", path)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	for _, rec := range strings.Split(string(out), "\x00") {
+		f := strings.Fields(rec)
+		if len(f) >= 2 && f[0] == "160000" {
+			return f[1]
+		}
+	}
+	return ""
+}
+
+// submoduleStates maps each changed submodule path to the "<c><m><u>"
+// flags of `git status --porcelain=v2`, where C means new commits, M
+// modified content and U untracked content; "." means unchanged.
+func submoduleStates(repoPath string) (map[string]string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "-c", "core.quotePath=false", "status", "--porcelain=v2", "-z", "--ignore-submodules=none", "--untracked-files=no")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("status failed: %v", err)
+	}
+	return parseSubmoduleStates(string(out)), nil
+}
+
+func parseSubmoduleStates(out string) map[string]string {
+	states := make(map[string]string)
+	recs := strings.Split(out, "\x00")
+	for i := 0; i < len(recs); i++ {
+		f := strings.Fields(recs[i])
+		if len(f) < 3 {
+			continue
+		}
+		switch f[0] {
+		case "1":
+			// 1 XY sub mH mI mW hH hI path
+			if len(f) >= 9 && strings.HasPrefix(f[2], "S") {
+				states[strings.SplitN(recs[i], " ", 9)[8]] = f[2][1:]
+			}
+		case "2":
+			// 2 XY sub mH mI mW hH hI Xscore path, then the original path.
+			if len(f) >= 10 && strings.HasPrefix(f[2], "S") {
+				states[strings.SplitN(recs[i], " ", 10)[9]] = f[2][1:]
+			}
+			i++
+		}
+	}
+	return states
+}
+
+// runSubmodule runs `git submodule <args>` and returns its output.
+func runSubmodule(repoPath string, args ...string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	cmd := exec.Command("git", append([]string{"-C", repoPath, "submodule"}, args...)...)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("submodule %s failed: %v\n%s", args[0], err, string(out))
+	}
+	return string(out), nil
+}
+
+// AddSubmodule clones url into path and registers it as a submodule,
+// optionally tracking branch.
+func AddSubmodule(repoPath, url, path, branch string) (string, error) {
+	url, path, branch = strings.TrimSpace(url), strings.TrimSpace(path), strings.TrimSpace(branch)
+	if url == "" || path == "" {
+		return "", errors.New("a URL and a path are required")
+	}
+	if strings.HasPrefix(url, "-") || strings.HasPrefix(path, "-") || strings.HasPrefix(branch, "-") {
+		return "", errors.New("invalid submodule argument")
+	}
+	args := []string{"add"}
+	if branch != "" {
+		args = append(args, "-b", branch)
+	}
+	args = append(args, "--", url, path)
+	return runSubmodule(repoPath, args...)
+}
+
+// InitSubmodules copies the URLs of paths, or of every submodule when paths
+// is empty, from .gitmodules into .git/config.
+func InitSubmodules(repoPath string, paths []string) (string, error) {
+	return runSubmodule(repoPath, append([]string{"init", "--"}, paths...)...)
+}
+
+// UpdateSubmodules checks out the recorded commit of each submodule, or
+// the remote branch tip when opts.Remote is set.
+func UpdateSubmodules(repoPath string, opts SubmoduleUpdateOptions) (string, error) {
+	args := []string{"update"}
+	if opts.Init {
+		args = append(args, "--init")
+	}
+	if opts.Remote {
+		args = append(args, "--remote")
+	}
+	if opts.Recursive {
+		args = append(args, "--recursive")
+	}
+	args = append(args, "--")
+	args = append(args, opts.Paths...)
+	return runSubmodule(repoPath, args...)
+}
+
+// SyncSubmodules updates the remote URLs of submodules after they changed
+// in .gitmodules.
+func SyncSubmodules(repoPath string, recursive bool, paths []string) (string, error) {
+	args := []string{"sync"}
+	if recursive {
+		args = append(args, "--recursive")
+	}
+	args = append(args, "--")
+	args = append(args, paths...)
+	return runSubmodule(repoPath, args...)
+}
+
+// DeinitSubmodule unregisters the submodule at path and empties its working
+// tree. force discards local changes in it.
+func DeinitSubmodule(repoPath, path string, force bool) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("a submodule path is required")
+	}
+	args := []string{"deinit"}
+	if force {
+		args = append(args, "--force")
+	}
+	args = append(args, "--", path)
+	return runSubmodule(repoPath, args...)
+}
+
+// RemoveSubmodule removes the submodule at path entirely: it is deinitialized,
+// its gitlink and .gitmodules entry are staged for removal and its cloned
+// repository under .git/modules is deleted.
+func RemoveSubmodule(repoPath, path string) (string, error) {
+	subs, err := ListSubmodules(repoPath)
+	if err != nil {
+		return "", err
+	}
+	path = filepath.ToSlash(strings.TrimSuffix(strings.TrimSpace(path), "/"))
+	var name string
+	for _, s := range subs {
+		if s.Path == path {
+			name = s.Name
+		}
+	}
+	if name == "" {
+		return "", fmt.Errorf("%s is not a submodule", path)
+	}
+	modulesDir, err := submoduleGitDir(repoPath, name)
+	if err != nil {
+		return "", err
+	}
+	if out, err := DeinitSubmodule(repoPath, path, true); err != nil {
+		return out, err
+	}
+	rm := exec.Command("git", "-C", repoPath, "rm", "-f", "--", path)
+	hideWindow(rm)
+	if out, err := rm.CombinedOutput(); err != nil {
+		return string(out), fmt.Errorf("removing submodule failed: %v\n%s", err, string(out))
+	}
+	if err := os.RemoveAll(modulesDir); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("removed submodule %s", path), nil
+}
+
+// submoduleGitDir returns the repository git keeps for the submodule name
+// under .git/modules. The name comes from .gitmodules, which a cloned
+// repository controls, so names that would lead outside .git/modules are
+// rejected.
+func submoduleGitDir(repoPath, name string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if clean == "." || filepath.IsAbs(clean) || filepath.VolumeName(clean) != "" || strings.HasPrefix(filepath.ToSlash(clean), "/") {
+		return "", fmt.Errorf("invalid submodule name %q", name)
+	}
+	for _, part := range strings.Split(filepath.ToSlash(clean), "/") {
+		if part == ".." {
+			return "", fmt.Errorf("invalid submodule name %q", name)
+		}
+	}
+	modules := filepath.Clean(gitPath(repoPath, "modules"))
+	dir := filepath.Join(modules, clean)
+	if rel, err := filepath.Rel(modules, dir); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid submodule name %q", name)
+	}
+	return dir, nil
+}
@@ -0,0 +1,125 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// allowFileSubmodules lets `git submodule` clone from local paths, which
+// git refuses by default.
+func allowFileSubmodules(t *testing.T) {
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+}
+
+func TestSubmoduleLifecycle(t *testing.T) {
+	allowFileSubmodules(t)
+	lib := initTestRepo(t)
+	dir := initTestRepo(t)
+
+	if _, err := AddSubmodule(dir, lib, "deps/lib", ""); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "commit", "-q", "-m", "add lib")
+
+	subs, err := ListSubmodules(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 {
+		t.Fatalf("expected one submodule, got %+v", subs)
+	}
+	s := subs[0]
+	if s.Name != "deps/lib" || s.Path != "deps/lib" || s.URL != lib || !s.Initialized || !s.CheckedOut {
+		t.Errorf("unexpected submodule: %+v", s)
+	}
+	if s.RecordedCommit == "" || s.RecordedCommit != s.CheckedOutCommit || s.Dirty() {
+		t.Errorf("expected a clean submodule: %+v", s)
+	}
+
+	subDir := filepath.Join(dir, "deps", "lib")
+	if err := os.WriteFile(filepath.Join(subDir, "README.md"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, subDir, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-q", "-am", "change lib")
+	if err := os.WriteFile(filepath.Join(subDir, "scratch.txt"), []byte("x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	subs, err = ListSubmodules(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := subs[0]; !s.NewCommits || s.ModifiedContent || !s.UntrackedContent || s.CheckedOutCommit == s.RecordedCommit {
+		t.Errorf("expected new commits and untracked content: %+v", s)
+	}
+
+	if _, err := UpdateSubmodules(dir, SubmoduleUpdateOptions{Paths: []string{"deps/lib"}}); err != nil {
+		t.Fatal(err)
+	}
+	subs, _ = ListSubmodules(dir)
+	if s := subs[0]; s.NewCommits || s.CheckedOutCommit != s.RecordedCommit {
+		t.Errorf("expected update to restore the recorded commit: %+v", s)
+	}
+
+	if _, err := DeinitSubmodule(dir, "deps/lib", true); err != nil {
+		t.Fatal(err)
+	}
+	subs, _ = ListSubmodules(dir)
+	if s := subs[0]; s.Initialized || s.CheckedOut {
+		t.Errorf("expected a deinitialized submodule: %+v", s)
+	}
+	if _, err := UpdateSubmodules(dir, SubmoduleUpdateOptions{Init: true, Recursive: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := RemoveSubmodule(dir, "deps/lib"); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "commit", "-q", "-m", "remove lib")
+	subs, err = ListSubmodules(dir)
+	if err != nil || len(subs) != 0 {
+		t.Errorf("expected no submodules: %+v %v", subs, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "modules", "deps", "lib")); !os.IsNotExist(err) {
+		t.Errorf("expected the module repository to be deleted: %v", err)
+	}
+}
+
+func TestParseGitmodules(t *testing.T) {
+	out := "submodule.a.b.path\nvendor/a.b\x00submodule.a.b.url\nhttps://example.com/a.git\x00submodule.a.b.branch\nmain\x00"
+	subs := parseGitmodules(out)
+	if len(subs) != 1 || subs[0].Name != "a.b" || subs[0].Path != "vendor/a.b" || subs[0].Branch != "main" || !strings.HasSuffix(subs[0].URL, "a.git") {
+		t.Errorf("unexpected submodules: %+v", subs)
+	}
+}
+
+func TestRemoveSubmoduleRejectsEscapingName(t *testing.T) {
+	dir := initTestRepo(t)
+	head := strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD"))
+	gitmodules := "[submodule \"../../victim\"]\n\tpath = sub\n\turl = https://example.com/sub.git\n"
+	if err := os.WriteFile(filepath.Join(dir, ".gitmodules"), []byte(gitmodules), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "update-index", "--add", "--cacheinfo", "160000,"+head+",sub")
+	gitRun(t, dir, "add", ".gitmodules")
+	gitRun(t, dir, "commit", "-q", "-m", "hostile submodule")
+
+	if _, err := RemoveSubmodule(dir, "sub"); err == nil || !strings.Contains(err.Error(), "invalid submodule name") {
+		t.Fatalf("expected the name to be rejected, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "README.md")); err != nil {
+		t.Errorf("repository content was touched: %v", err)
+	}
+
+	for _, name := range []string{"..", "a/../..", "/etc", "."} {
+		if _, err := submoduleGitDir(dir, name); err == nil {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+	if got, err := submoduleGitDir(dir, "libs/core"); err != nil || !strings.HasSuffix(filepath.ToSlash(got), ".git/modules/libs/core") {
+		t.Errorf("unexpected dir %q, %v", got, err)
+	}
+}
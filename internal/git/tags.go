@@ -0,0 +1,237 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TagInfo describes a lightweight or annotated tag.
+type TagInfo struct {
+	Name      string
+	Target    string
+	Annotated bool
+	Tagger    string
+	Date      time.Time
+	Subject   string
+	Message   string
+	Signed    bool
+	// SignatureStatus is empty for unsigned tags, "valid" when
+	// `git verify-tag` succeeds and "unverified" otherwise (bad signature
+	// or missing public key).
+	SignatureStatus string
+}
+
+// TagOptions controls how CreateTag creates a tag.
+type TagOptions struct {
+	// Message makes the tag annotated. Signed tags require a message.
+	Message string
+	// Target is the commit to tag. Empty means HEAD.
+	Target string
+	// Sign creates a GPG or SSH signed tag using the configured user.signingkey.
+	Sign bool
+	// SigningKey selects a specific key and implies Sign.
+	SigningKey string
+}
+
+// tagFormat is the for-each-ref format used by ListTags. Records end with
+// an ASCII record separator because tag messages may span several lines.
+const tagFormat = "%(refname:short)%00%(objecttype)%00%(objectname)%00%(*objectname)%00" +
+	"%(taggername) %(taggeremail)%00%(creatordate:unix)%00%(contents:subject)%00%(contents:body)%00%(contents:signature)%1e"
+
+// ListTags returns typed information for every tag, including whether
+// signed tags verify.
+func ListTags(repoPath string) ([]TagInfo, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("git", "-C", repoPath, "for-each-ref", "--sort=-creatordate", "--format="+tagFormat, "refs/tags")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing tags failed: %v", err)
+	}
+	tags := parseTagDetails(string(out))
+	for i := range tags {
+		if !tags[i].Signed {
+			continue
+		}
+		verify := exec.Command("git", "-C", repoPath, "verify-tag", "--", tags[i].Name)
+		hideWindow(verify)
+		if verify.Run() == nil {
+			tags[i].SignatureStatus = "valid"
+		} else {
+			tags[i].SignatureStatus = "unverified"
+		}
+	}
+	return tags, nil
+}
+
+// parseTagDetails converts for-each-ref output produced with tagFormat.
+func parseTagDetails(out string) []TagInfo {
+	var tags []TagInfo
+	for _, record := range strings.Split(out, "\x1e") {
+		record = strings.TrimPrefix(record, "\n")
+		if record == "" {
+			continue
+		}
+		fields := strings.Split(record, "\x00")
+		if len(fields) != 9 {
+			continue
+		}
+		info := TagInfo{
+			Name:      fields[0],
+			Annotated: fields[1] == "tag",
+			Target:    fields[2],
+			Subject:   fields[6],
+			Message:   strings.TrimSpace(fields[6] + "\n\n" + fields[7]),
+			Signed:    strings.TrimSpace(fields[8]) != "",
+		}
+		if info.Annotated {
+			if fields[3] != "" {
+				info.Target = fields[3]
+			}
+			info.Tagger = strings.TrimSpace(fields[4])
+		}
+		if secs, err := strconv.ParseInt(fields[5], 10, 64); err == nil {
+			info.Date = time.Unix(secs, 0)
+		}
+		tags = append(tags, info)
+	}
+	return tags
+}
+
+// CreateTag creates a tag named name. A message makes it annotated, and
+// Sign or SigningKey make it a signed tag.
+func CreateTag(repoPath, name string, opts TagOptions) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	name = strings.TrimSpace(name)
+	if err := validateTagName(repoPath, name); err != nil {
+		return "", err
+	}
+	target := strings.TrimSpace(opts.Target)
+	if strings.HasPrefix(target, "-") {
+		return "", errors.New("invalid tag target")
+	}
+	msg := strings.TrimSpace(opts.Message)
+	sign := opts.Sign || strings.TrimSpace(opts.SigningKey) != ""
+	if sign && msg == "" {
+		return "", errors.New("signed tags require a message")
+	}
+
+	args := []string{"-C", repoPath, "tag"}
+	switch {
+	case opts.SigningKey != "":
+		args = append(args, "-u", strings.TrimSpace(opts.SigningKey))
+	case sign:
+		args = append(args, "-s")
+	case msg != "":
+		args = append(args, "-a")
+	}
+	if msg != "" {
+		args = append(args, "-m", msg)
+	}
+	args = append(args, "--", name)
+	if target != "" {
+		args = append(args, target)
+	}
+
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("creating tag failed: %v\n%s", err, string(out))
+	}
+	return "Tag " + name + " created successfully.", nil
+}
+
+// validateTagName checks name against Git's ref naming rules.
+func validateTagName(repoPath, name string) error {
+	if name == "" {
+		return errors.New("tag name cannot be empty")
+	}
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid tag name %q", name)
+	}
+	cmd := exec.Command("git", "-C", repoPath, "check-ref-format", "refs/tags/"+name)
+	hideWindow(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("invalid tag name %q", name)
+	}
+	return nil
+}
+
+// PushTag pushes a single tag to remote. An empty remote defaults to origin.
+func PushTag(repoPath, remote, name string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	remote = defaultRemote(remote)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("tag name cannot be empty for push")
+	}
+	if strings.HasPrefix(remote, "-") {
+		return "", errors.New("invalid remote name")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "push", remote, "refs/tags/"+name)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("pushing tag failed: %v\n%s", err, string(out))
+	}
+	return "Tag " + name + " pushed to " + remote + ".", nil
+}
+
+// PushAllTags pushes every local tag to remote. An empty remote defaults to origin.
+func PushAllTags(repoPath, remote string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	remote = defaultRemote(remote)
+	if strings.HasPrefix(remote, "-") {
+		return "", errors.New("invalid remote name")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "push", remote, "--tags")
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("pushing tags failed: %v\n%s", err, string(out))
+	}
+	return string(out), nil
+}
+
+// DeleteRemoteTag deletes tag name on remote. An empty remote defaults to origin.
+func DeleteRemoteTag(repoPath, remote, name string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	remote = defaultRemote(remote)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("tag name cannot be empty")
+	}
+	if strings.HasPrefix(remote, "-") {
+		return "", errors.New("invalid remote name")
+	}
+	cmd := exec.Command("git", "-C", repoPath, "push", remote, "--delete", "refs/tags/"+name)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("deleting remote tag failed: %v\n%s", err, string(out))
+	}
+	return "Deleted tag " + name + " from " + remote + ".", nil
+}
+
+// defaultRemote returns remote, or origin when it is empty.
+func defaultRemote(remote string) string {
+	if remote = strings.TrimSpace(remote); remote == "" {
+		return "origin"
+	}
+	return remote
+}
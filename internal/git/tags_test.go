@@ -0,0 +1,50 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTagDetails(t *testing.T) {
+	out := "v2.0.0\x00tag\x00aaa111\x00bbb222\x00Alice <alice@example.com>\x001700000000\x00Release 2.0\x00Highlights:\n- faster\n\x00-----BEGIN PGP SIGNATURE-----\nxyz\n-----END PGP SIGNATURE-----\n\x1e\n" +
+		"v1.0.0\x00commit\x00ccc333\x00\x00 \x001600000000\x00initial commit\x00\x00\x1e\n"
+	tags := parseTagDetails(out)
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 tags, got %d: %+v", len(tags), tags)
+	}
+	annotated := tags[0]
+	if !annotated.Annotated || annotated.Target != "bbb222" || annotated.Tagger != "Alice <alice@example.com>" || !annotated.Signed {
+		t.Errorf("unexpected annotated tag: %+v", annotated)
+	}
+	if !strings.HasPrefix(annotated.Message, "Release 2.0\n\nHighlights:") {
+		t.Errorf("unexpected message: %q", annotated.Message)
+	}
+	light := tags[1]
+	if light.Annotated || light.Target != "ccc333" || light.Tagger != "" || light.Signed || light.Date.Unix() != 1600000000 {
+		t.Errorf("unexpected lightweight tag: %+v", light)
+	}
+}
+
+func TestCreateAnnotatedTag(t *testing.T) {
+	dir := initTestRepo(t)
+	first := strings.TrimSpace(gitRun(t, dir, "rev-parse", "HEAD"))
+	gitRun(t, dir, "commit", "--allow-empty", "-m", "second")
+
+	if _, err := CreateTag(dir, "v1.0.0", TagOptions{Message: "First release", Target: first}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateTag(dir, "bad..tag", TagOptions{}); err == nil {
+		t.Error("expected invalid tag name to be rejected")
+	}
+	if _, err := CreateTag(dir, "v1.0.1", TagOptions{Sign: true}); err == nil {
+		t.Error("expected signed tag without message to be rejected")
+	}
+
+	tags, err := ListTags(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 1 || !tags[0].Annotated || tags[0].Target != first || tags[0].Subject != "First release" {
+		t.Fatalf("unexpected tags: %+v", tags)
+	}
+}
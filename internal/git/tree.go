@@ -0,0 +1,287 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxBlobPreviewBytes bounds the blobs ReadTreeBlob returns; larger files
+// must be exported instead.
+const maxBlobPreviewBytes = 16 << 20
+
+// TreeEntry is one entry of a tree listed by ListTree.
+type TreeEntry struct {
+	Name string
+	// Path is relative to the repository root.
+	Path string
+	Mode string
+	// Type is "blob", "tree" or "commit" (a submodule).
+	Type string
+	Hash string
+	// Size is the blob size in bytes, or -1 for trees and submodules.
+	Size       int64
+	Submodule  bool
+	Symlink    bool
+	Executable bool
+}
+
+// BlobContent is a file read from a tree. Text files fill Content; binary
+// files fill Data with base64.
+type BlobContent struct {
+	Path    string
+	Hash    string
+	Size    int64
+	Binary  bool
+	Content string
+	Data    string
+}
+
+// cleanTreePath normalizes a repository-relative directory or file path.
+// The root is returned as "".
+func cleanTreePath(p string) (string, error) {
+	p = path.Clean("/" + filepath.ToSlash(strings.TrimSpace(p)))
+	p = strings.TrimPrefix(p, "/")
+	if strings.HasPrefix(p, "-") {
+		return "", fmt.Errorf("invalid path %q", p)
+	}
+	return p, nil
+}
+
+// treeRevision validates rev, defaulting to HEAD.
+func treeRevision(rev string) (string, error) {
+	rev = strings.TrimSpace(rev)
+	if rev == "" {
+		return "HEAD", nil
+	}
+	if strings.HasPrefix(rev, "-") {
+		return "", errors.New("invalid revision")
+	}
+	return rev, nil
+}
+
+// ListTree lists the directory dir as of rev (HEAD when empty) with `git
+// ls-tree -z -l`. It reads only the object database and never touches the
+// working tree.
+func ListTree(repoPath, rev, dir string) ([]TreeEntry, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	rev, err := treeRevision(rev)
+	if err != nil {
+		return nil, err
+	}
+	dir, err = cleanTreePath(dir)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("git", "-C", repoPath, "ls-tree", "-z", "-l", rev+":"+dir)
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing tree failed: %v", err)
+	}
+	return parseLsTree(string(out), dir), nil
+}
+
+// parseLsTree parses `git ls-tree -z -l` records of the form
+// "<mode> <type> <hash> <size>\t<name>".
+func parseLsTree(out, dir string) []TreeEntry {
+	entries := []TreeEntry{}
+	for _, rec := range strings.Split(out, "\x00") {
+		meta, name, ok := strings.Cut(rec, "\t")
+		if !ok {
+			continue
+		}
+		f := strings.Fields(meta)
+		if len(f) < 4 {
+			continue
+		}
+		e := TreeEntry{Name: name, Path: path.Join(dir, name), Mode: f[0], Type: f[1], Hash: f[2], Size: -1}
+		if f[3] != "-" {
+			e.Size, _ = strconv.ParseInt(f[3], 10, 64)
+		}
+		e.Submodule = e.Mode == "160000"
+		e.Symlink = e.Mode == "120000"
+		e.Executable = e.Mode == "100755"
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// ReadTreeBlob reads the file at filePath as of rev (HEAD when empty).
+func ReadTreeBlob(repoPath, rev, filePath string) (BlobContent, error) {
+	var blob BlobContent
+	if err := validateGitRepo(repoPath); err != nil {
+		return blob, err
+	}
+	rev, err := treeRevision(rev)
+	if err != nil {
+		return blob, err
+	}
+	if blob.Path, err = cleanTreePath(filePath); err != nil || blob.Path == "" {
+		return blob, fmt.Errorf("invalid path %q", filePath)
+	}
+	spec := rev + ":" + blob.Path
+	info := exec.Command("git", "-C", repoPath, "cat-file", "--batch-check=%(objectname) %(objecttype) %(objectsize)")
+	info.Stdin = strings.NewReader(spec + "\n")
+	hideWindow(info)
+	out, err := info.Output()
+	if err != nil {
+		return blob, fmt.Errorf("reading %s failed: %v", spec, err)
+	}
+	f := strings.Fields(string(out))
+	if len(f) != 3 {
+		return blob, fmt.Errorf("%s does not exist", spec)
+	}
+	if f[1] != "blob" {
+		return blob, fmt.Errorf("%s is a %s, not a file", spec, f[1])
+	}
+	blob.Hash = f[0]
+	blob.Size, _ = strconv.ParseInt(f[2], 10, 64)
+	if blob.Size > maxBlobPreviewBytes {
+		return blob, fmt.Errorf("%s is too large to preview (%d bytes); export it instead", blob.Path, blob.Size)
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "cat-file", "blob", blob.Hash)
+	hideWindow(cmd)
+	data, err := cmd.Output()
+	if err != nil {
+		return blob, fmt.Errorf("reading blob failed: %v", err)
+	}
+	if bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+		blob.Binary = true
+		blob.Data = base64.StdEncoding.EncodeToString(data)
+	} else {
+		blob.Content = string(data)
+	}
+	return blob, nil
+}
+
+// LastCommitsForTree returns, for each entry of dir as of rev, the most
+// recent commit that changed it, keyed by entry name. It walks the history
+// once and stops as soon as every entry has been seen.
+func LastCommitsForTree(repoPath, rev, dir string) (map[string]CommitSummary, error) {
+	entries, err := ListTree(repoPath, rev, dir)
+	if err != nil {
+		return nil, err
+	}
+	rev, _ = treeRevision(rev)
+	dir, _ = cleanTreePath(dir)
+	pending := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		pending[e.Name] = true
+	}
+	result := make(map[string]CommitSummary, len(entries))
+	if len(entries) == 0 {
+		return result, nil
+	}
+
+	args := []string{"-C", repoPath, "-c", "core.quotePath=false", "log", "--name-only",
+		"--format=%x1e%H%x00%h%x00%an%x00%ae%x00%at%x00%s", rev, "--"}
+	if dir != "" {
+		args = append(args, dir)
+	}
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("log failed: %v", err)
+	}
+	var current CommitSummary
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for len(pending) > 0 && scanner.Scan() {
+		line := scanner.Text()
+		if header, ok := strings.CutPrefix(line, "\x1e"); ok {
+			if f := strings.SplitN(header, "\x00", 6); len(f) == 6 {
+				current = commitSummaryFields(f)
+			}
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		name := unquoteDiffPath(line)
+		if dir != "" {
+			name = strings.TrimPrefix(name, dir+"/")
+		}
+		name, _, _ = strings.Cut(name, "/")
+		if pending[name] {
+			result[name] = current
+			delete(pending, name)
+		}
+	}
+	// Stop git once every entry is resolved; its exit status is irrelevant then.
+	_ = cmd.Process.Kill()
+	_ = cmd.Wait()
+	return result, nil
+}
+
+// ExportTreePath writes the file or directory at treePath as of rev (HEAD
+// when empty) to dest. Files are written as-is; directories, and the whole
+// tree when treePath is empty, are written as an archive whose format
+// `git archive` picks from dest's extension (.zip, .tar, .tar.gz or .tgz).
+// An existing dest is only replaced when overwrite is set, e.g. after the
+// user confirmed it in a save dialog, and never when it is a directory.
+func ExportTreePath(repoPath, rev, treePath, dest string, overwrite bool) error {
+	if err := validateGitRepo(repoPath); err != nil {
+		return err
+	}
+	rev, err := treeRevision(rev)
+	if err != nil {
+		return err
+	}
+	if treePath, err = cleanTreePath(treePath); err != nil {
+		return err
+	}
+	dest = strings.TrimSpace(dest)
+	if dest == "" {
+		return errors.New("a destination is required")
+	}
+	if dest, err = filepath.Abs(dest); err != nil {
+		return err
+	}
+	if info, err := os.Stat(dest); err == nil && (!overwrite || info.IsDir()) {
+		return fmt.Errorf("%s already exists", dest)
+	}
+
+	typeCmd := exec.Command("git", "-C", repoPath, "cat-file", "-t", rev+":"+treePath)
+	hideWindow(typeCmd)
+	out, err := typeCmd.Output()
+	if err != nil {
+		return fmt.Errorf("%s does not exist at %s", treePath, rev)
+	}
+	if strings.TrimSpace(string(out)) == "blob" {
+		cmd := exec.Command("git", "-C", repoPath, "cat-file", "blob", rev+":"+treePath)
+		hideWindow(cmd)
+		data, err := cmd.Output()
+		if err != nil {
+			return fmt.Errorf("reading blob failed: %v", err)
+		}
+		return os.WriteFile(dest, data, 0644)
+	}
+
+	args := []string{"-C", repoPath, "archive", "--output", dest, rev}
+	if treePath != "" {
+		args = append(args, "--", treePath)
+	}
+	cmd := exec.Command("git", args...)
+	hideWindow(cmd)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("archive failed: %v\n%s", err, string(out))
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package git
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupTreeRepo(t *testing.T) string {
+	t.Helper()
+	dir := initTestRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, "src", "pkg"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "pkg", "lib.go"), []byte("package pkg\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "run.sh"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "update-index", "--add", "--cacheinfo", "160000,"+strings.Repeat("a", 40)+",vendor/dep")
+	gitRun(t, dir, "commit", "-q", "-m", "add sources")
+	if err := os.WriteFile(filepath.Join(dir, "src", "pkg", "lib.go"), []byte("package pkg\n\nfunc F() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "add", "src")
+	gitRun(t, dir, "commit", "-q", "-m", "add F")
+	return dir
+}
+
+func TestListTree(t *testing.T) {
+	dir := setupTreeRepo(t)
+	entries, err := ListTree(dir, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]TreeEntry)
+	for _, e := range entries {
+		byName[e.Name] = e
+	}
+	if e := byName["README.md"]; e.Type != "blob" || e.Size != 6 || e.Executable {
+		t.Errorf("unexpected README entry: %+v", e)
+	}
+	if e := byName["run.sh"]; !e.Executable {
+		t.Errorf("expected executable run.sh: %+v", e)
+	}
+	if e := byName["src"]; e.Type != "tree" || e.Size != -1 {
+		t.Errorf("unexpected src entry: %+v", e)
+	}
+
+	vendor, err := ListTree(dir, "HEAD", "vendor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vendor) != 1 || !vendor[0].Submodule || vendor[0].Path != "vendor/dep" {
+		t.Errorf("unexpected submodule entry: %+v", vendor)
+	}
+
+	old, err := ListTree(dir, "HEAD~2", "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(old) != 1 || old[0].Name != "README.md" {
+		t.Errorf("unexpected tree at first commit: %+v", old)
+	}
+}
+
+func TestReadTreeBlob(t *testing.T) {
+	dir := setupTreeRepo(t)
+	blob, err := ReadTreeBlob(dir, "HEAD~1", "src/pkg/lib.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blob.Binary || blob.Content != "package pkg\n" || blob.Size != 12 || blob.Hash == "" {
+		t.Errorf("unexpected blob: %+v", blob)
+	}
+	if _, err := ReadTreeBlob(dir, "HEAD", "src"); err == nil {
+		t.Error("expected an error reading a directory")
+	}
+	if _, err := ReadTreeBlob(dir, "HEAD", "missing.txt"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestLastCommitsForTree(t *testing.T) {
+	dir := setupTreeRepo(t)
+	commits, err := LastCommitsForTree(dir, "", "src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commits["pkg"].Subject != "add F" || commits["main.go"].Subject != "add sources" {
+		t.Errorf("unexpected last commits: %+v", commits)
+	}
+	root, err := LastCommitsForTree(dir, "HEAD", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root["README.md"].Subject != "initial commit" || root["run.sh"].Subject != "add sources" {
+		t.Errorf("missing README commit: %+v", root)
+	}
+}
+
+func TestExportTreePath(t *testing.T) {
+	dir := setupTreeRepo(t)
+	out := t.TempDir()
+
+	file := filepath.Join(out, "lib.go")
+	if err := ExportTreePath(dir, "HEAD~1", "src/pkg/lib.go", file, false); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != "package pkg\n" {
+		t.Errorf("unexpected exported file: %q", data)
+	}
+	if err := ExportTreePath(dir, "HEAD", "src/pkg/lib.go", file, false); err == nil {
+		t.Error("expected an existing destination to be refused")
+	}
+	if err := ExportTreePath(dir, "HEAD", "src/pkg/lib.go", file, true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file); !strings.Contains(string(data), "func F()") {
+		t.Errorf("expected confirmed overwrite, got %q", data)
+	}
+	if err := ExportTreePath(dir, "HEAD", "src/pkg/lib.go", out, true); err == nil {
+		t.Error("expected a directory destination to be refused")
+	}
+
+	archive := filepath.Join(out, "src.zip")
+	if err := ExportTreePath(dir, "HEAD", "src", archive, false); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if !strings.Contains(strings.Join(names, ","), "src/pkg/lib.go") {
+		t.Errorf("unexpected archive contents: %v", names)
+	}
+}
@@ -0,0 +1,279 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// WorktreeInfo is one entry of `git worktree list --porcelain`.
+type WorktreeInfo struct {
+	Path string
+	Head string
+	// Branch is the short name of the checked-out branch, empty when
+	// Detached or Bare.
+	Branch   string
+	Bare     bool
+	Detached bool
+	// Main is set for the repository's main working tree.
+	Main       bool
+	Locked     bool
+	LockReason string
+	// Prunable is set when the working tree is missing and would be removed
+	// by `git worktree prune`.
+	Prunable       bool
+	PrunableReason string
+}
+
+// WorktreeAddOptions controls AddWorktree.
+type WorktreeAddOptions struct {
+	Path string
+	// Commit is the branch or commit to check out; empty uses HEAD, or a
+	// branch named after the last path component when NewBranch is empty.
+	Commit string
+	// NewBranch creates a branch with this name at Commit.
+	NewBranch string
+	// Detach checks out Commit with a detached HEAD.
+	Detach bool
+	// Track sets Commit, a remote-tracking branch, as the upstream of NewBranch.
+	Track bool
+	Lock  bool
+	Force bool
+}
+
+// ListWorktrees returns every working tree attached to the repository, the
+// main one first.
+func ListWorktrees(repoPath string) ([]WorktreeInfo, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("git", "-C", repoPath, "worktree", "list", "--porcelain", "-z")
+	hideWindow(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("worktree list failed: %v", err)
+	}
+	return parseWorktreeList(string(out)), nil
+}
+
+// parseWorktreeList parses `git worktree list --porcelain -z` output, in
+// which each attribute ends with a NUL and an empty attribute ends an entry.
+func parseWorktreeList(out string) []WorktreeInfo {
+	worktrees := []WorktreeInfo{}
+	var cur *WorktreeInfo
+	for _, attr := range strings.Split(out, "\x00") {
+		if attr == "" {
+			cur = nil
+			continue
+		}
+		key, value, _ := strings.Cut(attr, " ")
+		if key == "worktree" {
+			worktrees = append(worktrees, WorktreeInfo{Path: filepath.FromSlash(value), Main: len(worktrees) == 0})
+			cur = &worktrees[len(worktrees)-1]
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		switch key {
+		case "HEAD":
+			cur.Head = value
+		case "branch":
+			cur.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			cur.Bare = true
+		case "detached":
+			cur.Detached = true
+		case "locked":
+			cur.Locked = true
+			cur.LockReason = value
+		case "prunable":
+			cur.Prunable = true
+			cur.PrunableReason = value
+		}
+	}
+	return worktrees
+}
+
+// FindWorktree returns the working tree of the repository located at path.
+func FindWorktree(repoPath, path string) (WorktreeInfo, error) {
+	worktrees, err := ListWorktrees(repoPath)
+	if err != nil {
+		return WorktreeInfo{}, err
+	}
+	want := canonicalPath(path)
+	for _, wt := range worktrees {
+		if canonicalPath(wt.Path) == want {
+			return wt, nil
+		}
+	}
+	return WorktreeInfo{}, fmt.Errorf("%s is not a worktree of this repository", path)
+}
+
+func canonicalPath(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		p = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(p); err == nil {
+		p = resolved
+	}
+	return filepath.Clean(p)
+}
+
+func validWorktreeArg(arg string) error {
+	if strings.HasPrefix(arg, "-") {
+		return fmt.Errorf("invalid worktree argument %q", arg)
+	}
+	return nil
+}
+
+// runWorktree runs `git worktree <args>` and returns its output.
+func runWorktree(repoPath string, args ...string) (string, error) {
+	if err := validateGitRepo(repoPath); err != nil {
+		return "", err
+	}
+	cmd := exec.Command("git", append([]string{"-C", repoPath, "worktree"}, args...)...)
+	hideWindow(cmd)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("worktree %s failed: %v\n%s", args[0], err, string(out))
+	}
+	return string(out), nil
+}
+
+// AddWorktree creates a working tree at opts.Path.
+func AddWorktree(repoPath string, opts WorktreeAddOptions) (string, error) {
+	opts.Path = strings.TrimSpace(opts.Path)
+	opts.Commit = strings.TrimSpace(opts.Commit)
+	opts.NewBranch = strings.TrimSpace(opts.NewBranch)
+	if opts.Path == "" {
+		return "", errors.New("a worktree path is required")
+	}
+	for _, arg := range []string{opts.Path, opts.Commit, opts.NewBranch} {
+		if err := validWorktreeArg(arg); err != nil {
+			return "", err
+		}
+	}
+	if opts.Detach && opts.NewBranch != "" {
+		return "", errors.New("cannot create a branch and detach HEAD at the same time")
+	}
+	if opts.Track && (opts.NewBranch == "" || opts.Commit == "") {
+		return "", errors.New("tracking requires a new branch and the branch to track")
+	}
+
+	args := []string{"add"}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	if opts.Detach {
+		args = append(args, "--detach")
+	}
+	if opts.NewBranch != "" {
+		args = append(args, "-b", opts.NewBranch)
+	}
+	if opts.Track {
+		args = append(args, "--track")
+	}
+	if opts.Lock {
+		args = append(args, "--lock")
+	}
+	args = append(args, "--", opts.Path)
+	if opts.Commit != "" {
+		args = append(args, opts.Commit)
+	}
+	return runWorktree(repoPath, args...)
+}
+
+// RemoveWorktree deletes the working tree at path. force discards its
+// uncommitted changes.
+func RemoveWorktree(repoPath, path string, force bool) (string, error) {
+	if strings.TrimSpace(path) == "" {
+		return "", errors.New("a worktree path is required")
+	}
+	args := []string{"remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	return runWorktree(repoPath, append(args, "--", path)...)
+}
+
+// LockWorktree prevents the working tree at path from being pruned, moved
+// or removed, recording an optional reason.
+func LockWorktree(repoPath, path, reason string) (string, error) {
+	if strings.TrimSpace(path) == "" {
+		return "", errors.New("a worktree path is required")
+	}
+	args := []string{"lock"}
+	if reason = strings.TrimSpace(reason); reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	return runWorktree(repoPath, append(args, "--", path)...)
+}
+
+// UnlockWorktree removes the lock from the working tree at path.
+func UnlockWorktree(repoPath, path string) (string, error) {
+	if strings.TrimSpace(path) == "" {
+		return "", errors.New("a worktree path is required")
+	}
+	return runWorktree(repoPath, "unlock", "--", path)
+}
+
+// MoveWorktree moves the working tree at path to newPath.
+func MoveWorktree(repoPath, path, newPath string) (string, error) {
+	if strings.TrimSpace(path) == "" || strings.TrimSpace(newPath) == "" {
+		return "", errors.New("a worktree path and a destination are required")
+	}
+	return runWorktree(repoPath, "move", "--", path, newPath)
+}
+
+// RepairWorktrees fixes the links between the repository and its working
+// trees after they were moved by hand. paths lists working trees at new
+// locations; empty repairs the known ones.
+func RepairWorktrees(repoPath string, paths []string) (string, error) {
+	return runWorktree(repoPath, append([]string{"repair", "--"}, paths...)...)
+}
+
+// PruneWorktrees removes the records of working trees that no longer exist.
+func PruneWorktrees(repoPath string) (string, error) {
+	return runWorktree(repoPath, "prune", "--verbose")
+}
+
+// splitArgs splits s at whitespace, keeping text in single or double quotes
+// together so that paths may contain spaces.
+func splitArgs(s string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	var quote rune
+	inArg := false
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
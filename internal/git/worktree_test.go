@@ -0,0 +1,136 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWorktreeList(t *testing.T) {
+	out := "worktree /repo\x00HEAD aaa\x00branch refs/heads/main\x00\x00" +
+		"worktree /tmp/my tree\x00HEAD bbb\x00detached\x00locked on usb\x00\x00" +
+		"worktree /gone\x00HEAD ccc\x00branch refs/heads/feature/x\x00prunable gitdir file points to non-existent location\x00\x00"
+	got := parseWorktreeList(out)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 worktrees, got %+v", got)
+	}
+	if !got[0].Main || got[0].Branch != "main" || got[0].Head != "aaa" {
+		t.Errorf("unexpected main worktree: %+v", got[0])
+	}
+	if got[1].Main || !got[1].Detached || !got[1].Locked || got[1].LockReason != "on usb" || got[1].Path != filepath.FromSlash("/tmp/my tree") {
+		t.Errorf("unexpected detached worktree: %+v", got[1])
+	}
+	if got[2].Branch != "feature/x" || !got[2].Prunable || !strings.Contains(got[2].PrunableReason, "non-existent") {
+		t.Errorf("unexpected prunable worktree: %+v", got[2])
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	got, err := splitArgs(`"/tmp/my tree" feature  'a b'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/tmp/my tree", "feature", "a b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := splitArgs(`"open`); err == nil {
+		t.Error("expected unterminated quote to fail")
+	}
+}
+
+func TestWorktreeLifecycle(t *testing.T) {
+	dir := initTestRepo(t)
+	base := t.TempDir()
+	wtPath := filepath.Join(base, "feature tree")
+
+	if _, err := AddWorktree(dir, WorktreeAddOptions{Path: "--force"}); err == nil {
+		t.Fatal("expected option-like path to be rejected")
+	}
+	if _, err := AddWorktree(dir, WorktreeAddOptions{Path: wtPath, NewBranch: "feature"}); err != nil {
+		t.Fatal(err)
+	}
+	detached := filepath.Join(base, "detached")
+	if _, err := Worktree(dir, "Add", `"`+detached+`" main`); err == nil {
+		t.Fatal("expected main, already checked out, to be refused")
+	}
+	if _, err := AddWorktree(dir, WorktreeAddOptions{Path: detached, Commit: "main", Detach: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	worktrees, err := ListWorktrees(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(worktrees) != 3 || !worktrees[0].Main || worktrees[0].Branch != "main" {
+		t.Fatalf("unexpected worktrees: %+v", worktrees)
+	}
+	wt, err := FindWorktree(dir, wtPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wt.Branch != "feature" || wt.Head == "" {
+		t.Errorf("unexpected feature worktree: %+v", wt)
+	}
+	if wt, err := FindWorktree(dir, detached); err != nil || !wt.Detached {
+		t.Errorf("expected detached worktree: %+v, %v", wt, err)
+	}
+	if _, err := FindWorktree(dir, base); err == nil {
+		t.Error("expected unrelated directory to be rejected")
+	}
+
+	if _, err := LockWorktree(dir, wtPath, "in use"); err != nil {
+		t.Fatal(err)
+	}
+	if wt, _ := FindWorktree(dir, wtPath); !wt.Locked || wt.LockReason != "in use" {
+		t.Errorf("expected locked worktree: %+v", wt)
+	}
+	moved := filepath.Join(base, "moved tree")
+	if _, err := MoveWorktree(dir, wtPath, moved); err == nil {
+		t.Error("expected locked worktree move to fail")
+	}
+	if _, err := UnlockWorktree(dir, wtPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MoveWorktree(dir, wtPath, moved); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindWorktree(dir, moved); err != nil {
+		t.Fatal(err)
+	}
+
+	// Moving a worktree by hand breaks its link until it is repaired.
+	handMoved := filepath.Join(base, "by hand")
+	if err := os.Rename(moved, handMoved); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RepairWorktrees(dir, []string{handMoved}); err != nil {
+		t.Fatal(err)
+	}
+	if wt, err := FindWorktree(dir, handMoved); err != nil || wt.Prunable {
+		t.Errorf("expected repaired worktree: %+v, %v", wt, err)
+	}
+
+	if _, err := Worktree(dir, "Remove", `"`+handMoved+`"`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RemoveWorktree(dir, detached, false); err != nil {
+		t.Fatal(err)
+	}
+	if worktrees, _ := ListWorktrees(dir); len(worktrees) != 1 {
+		t.Errorf("expected only the main worktree: %+v", worktrees)
+	}
+
+	// A path alone creates a branch named after its last component.
+	hotfix := filepath.Join(base, "hotfix")
+	if _, err := Worktree(dir, "Add", hotfix); err != nil {
+		t.Fatal(err)
+	}
+	if wt, err := FindWorktree(dir, hotfix); err != nil || wt.Branch != "hotfix" {
+		t.Errorf("expected a hotfix branch worktree: %+v, %v", wt, err)
+	}
+	if _, err := Worktree(dir, "Add", ""); err == nil {
+		t.Error("expected a missing path to be rejected")
+	}
+}
@@ -0,0 +1,354 @@
+package highlight
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token classes returned in Span.Class.
+const (
+	Plain   = "plain"
+	Keyword = "keyword"
+	Literal = "literal"
+	String  = "string"
+	Number  = "number"
+	Comment = "comment"
+)
+
+// MaxBytes is the largest content Highlight tokenizes. Larger content is
+// returned as plain text.
+const MaxBytes = 512 * 1024
+
+// MaxLineLength marks content as minified when any line is longer.
+const MaxLineLength = 2000
+
+// Span is a run of text with a single token class.
+type Span struct {
+	Text  string
+	Class string
+}
+
+// Result is highlighted content split into lines.
+type Result struct {
+	Language string
+	// Plain is set when highlighting was skipped because the language is
+	// unknown or the content is binary, too large or minified.
+	Plain bool
+	Lines [][]Span
+}
+
+type language struct {
+	lineComments []string
+	blockComment [2]string
+	// strings lists string delimiters, longest first. Delimiters in
+	// multiline may span lines; raw strings ignore backslash escapes.
+	strings   []string
+	multiline map[string]bool
+	raw       map[string]bool
+	keywords  map[string]bool
+	literals  map[string]bool
+}
+
+func words(s string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
+
+var cLiterals = words("true false null NULL nullptr")
+
+var languages = map[string]*language{
+	"go": {
+		lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"},
+		strings: []string{`"`, "'", "`"}, multiline: words("`"), raw: words("`"),
+		keywords: words("break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var"),
+		literals: words("true false nil iota"),
+	},
+	"javascript": {
+		lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"},
+		strings: []string{`"`, "'", "`"}, multiline: words("`"),
+		keywords: words("async await break case catch class const continue debugger default delete do else export extends finally for from function if import in instanceof let new of return static super switch this throw try typeof var void while with yield interface type enum implements private protected public readonly"),
+		literals: words("true false null undefined NaN Infinity"),
+	},
+	"python": {
+		lineComments: []string{"#"},
+		strings:      []string{`"""`, `'''`, `"`, "'"}, multiline: words(`""" '''`),
+		keywords: words("and as assert async await break class continue def del elif else except finally for from global if import in is lambda nonlocal not or pass raise return try while with yield match case"),
+		literals: words("True False None"),
+	},
+	"rust": {
+		lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"},
+		strings:  []string{`"`},
+		keywords: words("as async await break const continue crate dyn else enum extern fn for if impl in let loop match mod move mut pub ref return self Self static struct super trait type unsafe use where while"),
+		literals: words("true false None Some Ok Err"),
+	},
+	"c": {
+		lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"},
+		strings:  []string{`"`, "'"},
+		keywords: words("auto break case char class const constexpr continue default delete do double else enum extern float for goto if inline int long namespace new private protected public register return short signed sizeof static struct switch template this throw try typedef typename union unsigned using virtual void volatile while #include #define #ifdef #ifndef #endif #if #else #pragma"),
+		literals: words("true false null NULL nullptr"),
+	},
+	"java": {
+		lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"},
+		strings: []string{`"""`, `"`, "'"}, multiline: words(`"""`),
+		keywords: words("abstract assert boolean break byte case catch char class const continue default do double else enum extends final finally float for if implements import instanceof int interface long native new package private protected public return short static super switch synchronized this throw throws try var void volatile while"),
+		literals: words("true false null"),
+	},
+	"shell": {
+		lineComments: []string{"#"},
+		strings:      []string{`"`, "'"}, multiline: words(`" '`), raw: words("'"),
+		keywords: words("if then else elif fi case esac for while until do done in function return local export readonly declare set unset shift exit"),
+		literals: words("true false"),
+	},
+	"css": {
+		blockComment: [2]string{"/*", "*/"},
+		strings:      []string{`"`, "'"},
+		keywords:     words("@media @import @keyframes @font-face @supports !important"),
+		literals:     words(""),
+	},
+	"json": {
+		strings:  []string{`"`},
+		keywords: words(""),
+		literals: words("true false null"),
+	},
+	"yaml": {
+		lineComments: []string{"#"},
+		strings:      []string{`"`, "'"},
+		keywords:     words(""),
+		literals:     words("true false null yes no on off"),
+	},
+}
+
+var extensions = map[string]string{
+	".go": "go",
+	".js": "javascript", ".mjs": "javascript", ".cjs": "javascript", ".jsx": "javascript",
+	".ts": "javascript", ".tsx": "javascript",
+	".py": "python", ".pyw": "python",
+	".rs": "rust",
+	".c":  "c", ".h": "c", ".cc": "c", ".cpp": "c", ".cxx": "c", ".hpp": "c",
+	".java": "java", ".kt": "java", ".kts": "java", ".scala": "java", ".cs": "java",
+	".sh": "shell", ".bash": "shell", ".zsh": "shell",
+	".css": "css", ".scss": "css", ".less": "css",
+	".json": "json",
+	".yml":  "yaml", ".yaml": "yaml",
+}
+
+var shebangs = map[string]string{
+	"sh": "shell", "bash": "shell", "zsh": "shell", "dash": "shell",
+	"python": "python", "python3": "python", "python2": "python",
+	"node": "javascript",
+}
+
+// Detect returns the language for filename, falling back to the shebang on
+// firstLine. It returns "" when the language is unknown.
+func Detect(filename, firstLine string) string {
+	if lang, ok := extensions[strings.ToLower(filepath.Ext(filename))]; ok {
+		return lang
+	}
+	if rest, ok := strings.CutPrefix(firstLine, "#!"); ok {
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return ""
+		}
+		interp := filepath.Base(fields[0])
+		if interp == "env" && len(fields) > 1 {
+			interp = fields[1]
+		}
+		return shebangs[interp]
+	}
+	return ""
+}
+
+// Highlight tokenizes content using the language detected from filename.
+func Highlight(filename string, content []byte) Result {
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	lang := Detect(filename, string(firstLine))
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	res := Result{Language: lang}
+	if lang == "" || !Suitable(content) {
+		res.Plain = true
+		for _, l := range lines {
+			res.Lines = append(res.Lines, []Span{{Text: l, Class: Plain}})
+		}
+		return res
+	}
+	h := New(lang)
+	for _, l := range lines {
+		res.Lines = append(res.Lines, h.Line(l))
+	}
+	return res
+}
+
+// Suitable reports whether content is small, textual and unminified enough
+// to be worth highlighting.
+func Suitable(content []byte) bool {
+	if len(content) > MaxBytes || bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content) {
+		return false
+	}
+	for len(content) > 0 {
+		line, rest, _ := bytes.Cut(content, []byte("\n"))
+		if len(line) > MaxLineLength {
+			return false
+		}
+		content = rest
+	}
+	return true
+}
+
+// Highlighter tokenizes consecutive lines of one language, carrying block
+// comments and multi-line strings from one line to the next.
+type Highlighter struct {
+	lang *language
+	// closer is the delimiter ending the open block comment or string.
+	closer  string
+	inClass string
+	raw     bool
+}
+
+// New returns a Highlighter for lang, or nil when lang is unknown.
+func New(lang string) *Highlighter {
+	l, ok := languages[lang]
+	if !ok {
+		return nil
+	}
+	return &Highlighter{lang: l}
+}
+
+// Line tokenizes the next line. A nil Highlighter returns the line as plain text.
+func (h *Highlighter) Line(line string) []Span {
+	if h == nil {
+		return []Span{{Text: line, Class: Plain}}
+	}
+	var spans []Span
+	emit := func(text, class string) {
+		if text == "" {
+			return
+		}
+		if n := len(spans); n > 0 && spans[n-1].Class == class {
+			spans[n-1].Text += text
+			return
+		}
+		spans = append(spans, Span{Text: text, Class: class})
+	}
+
+	pos := 0
+	for pos < len(line) {
+		if h.closer != "" {
+			end := h.findCloser(line, pos)
+			if end < 0 {
+				emit(line[pos:], h.inClass)
+				break
+			}
+			emit(line[pos:end], h.inClass)
+			h.closer = ""
+			pos = end
+			continue
+		}
+		rest := line[pos:]
+
+		if prefix := matchPrefix(rest, h.lang.lineComments); prefix != "" {
+			emit(rest, Comment)
+			return spans
+		}
+		if open := h.lang.blockComment[0]; open != "" && strings.HasPrefix(rest, open) {
+			emit(open, Comment)
+			h.closer, h.inClass, h.raw = h.lang.blockComment[1], Comment, true
+			pos += len(open)
+			continue
+		}
+		if delim := matchPrefix(rest, h.lang.strings); delim != "" {
+			emit(delim, String)
+			h.closer, h.inClass, h.raw = delim, String, h.lang.raw[delim]
+			pos += len(delim)
+			end := h.findCloser(line, pos)
+			if end < 0 {
+				emit(line[pos:], String)
+				if !h.lang.multiline[delim] {
+					h.closer = ""
+				}
+				return spans
+			}
+			emit(line[pos:end], String)
+			h.closer = ""
+			pos = end
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		switch {
+		case unicode.IsDigit(r):
+			end := pos + scanWhile(rest, func(r rune) bool {
+				return unicode.IsDigit(r) || unicode.IsLetter(r) || r == '.' || r == '_'
+			})
+			emit(line[pos:end], Number)
+			pos = end
+		case isIdentStart(r):
+			end := pos + size + scanWhile(rest[size:], isIdentPart)
+			word := line[pos:end]
+			switch {
+			case h.lang.keywords[word]:
+				emit(word, Keyword)
+			case h.lang.literals[word]:
+				emit(word, Literal)
+			default:
+				emit(word, Plain)
+			}
+			pos = end
+		default:
+			emit(rest[:size], Plain)
+			pos += size
+		}
+	}
+	if spans == nil {
+		spans = []Span{}
+	}
+	return spans
+}
+
+// findCloser returns the index just past the open string or comment's
+// closing delimiter in line, starting at pos, or -1 if it is not on this line.
+func (h *Highlighter) findCloser(line string, pos int) int {
+	for i := pos; i < len(line); i++ {
+		if !h.raw && line[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(line[i:], h.closer) {
+			return i + len(h.closer)
+		}
+	}
+	return -1
+}
+
+func matchPrefix(s string, prefixes []string) string {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return p
+		}
+	}
+	return ""
+}
+
+func scanWhile(s string, ok func(rune) bool) int {
+	for i, r := range s {
+		if !ok(r) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_' || r == '$' || r == '@' || r == '#' || r == '!'
+}
+
+func isIdentPart(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$'
+}
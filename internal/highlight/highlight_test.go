@@ -0,0 +1,87 @@
+package highlight
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	cases := []struct{ name, first, want string }{
+		{"main.go", "", "go"},
+		{"App.TSX", "", "javascript"},
+		{"deploy", "#!/usr/bin/env python3", "python"},
+		{"run", "#!/bin/bash -e", "shell"},
+		{"README", "hello", ""},
+	}
+	for _, c := range cases {
+		if got := Detect(c.name, c.first); got != c.want {
+			t.Errorf("Detect(%q, %q) = %q, want %q", c.name, c.first, got, c.want)
+		}
+	}
+}
+
+func classes(spans []Span) map[string]string {
+	m := make(map[string]string)
+	for _, s := range spans {
+		m[strings.TrimSpace(s.Text)] = s.Class
+	}
+	return m
+}
+
+func TestHighlightLine(t *testing.T) {
+	h := New("go")
+	got := classes(h.Line(`	return "a\"b", 42 // done`))
+	if got["return"] != Keyword || got[`"a\"b"`] != String || got["42"] != Number || got["// done"] != Comment {
+		t.Errorf("unexpected spans: %v", got)
+	}
+	if got := classes(h.Line("x := nil")); got["nil"] != Literal {
+		t.Errorf("expected nil literal: %v", got)
+	}
+}
+
+func TestHighlightSigilWords(t *testing.T) {
+	cases := []struct{ lang, line, word, want string }{
+		{"go", "return !x", "!x", Plain},
+		{"go", "if !ok {", "if", Keyword},
+		{"python", "@decorator", "@decorator", Plain},
+		{"c", "#include <stdio.h>", "#include", Keyword},
+		{"css", "color: red !important;", "!important", Keyword},
+		{"java", "@Override", "@Override", Plain},
+	}
+	for _, c := range cases {
+		if got := classes(New(c.lang).Line(c.line)); got[c.word] != c.want {
+			t.Errorf("%s %q: %q is %q, want %q (%v)", c.lang, c.line, c.word, got[c.word], c.want, got)
+		}
+	}
+}
+
+func TestHighlightCarriesState(t *testing.T) {
+	h := New("go")
+	h.Line("/* start")
+	if spans := h.Line("still comment */ if"); spans[0].Class != Comment || spans[len(spans)-1].Class != Keyword {
+		t.Errorf("block comment not carried over: %+v", spans)
+	}
+	h.Line("s := `raw")
+	if spans := h.Line(`\` + "`"); len(spans) != 1 || spans[0].Class != String {
+		t.Errorf("raw string not carried over: %+v", spans)
+	}
+	h.Line(`x := "unterminated`)
+	if spans := h.Line("for"); spans[0].Class != Keyword {
+		t.Errorf("single-line string leaked into next line: %+v", spans)
+	}
+}
+
+func TestHighlightFallback(t *testing.T) {
+	res := Highlight("app.js", []byte("var a = 1;\n"+strings.Repeat("x", MaxLineLength+1)+"\n"))
+	if !res.Plain || res.Language != "javascript" || len(res.Lines) != 2 {
+		t.Errorf("expected minified file as plain text: %+v", res.Language)
+	}
+	res = Highlight("notes.txt", []byte("plain\n"))
+	if !res.Plain || len(res.Lines) != 1 || res.Lines[0][0].Text != "plain" {
+		t.Errorf("unexpected plain result: %+v", res)
+	}
+	res = Highlight("x.py", []byte("def f():\n    pass\n"))
+	if res.Plain || len(res.Lines) != 2 || res.Lines[0][0].Class != Keyword {
+		t.Errorf("unexpected python result: %+v", res)
+	}
+}
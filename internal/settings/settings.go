@@ -0,0 +1,103 @@
+package settings
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// MaxCommitHistory is the number of recent commit messages kept per repository.
+const MaxCommitHistory = 20
+
+// FilePath overrides the settings file location. When empty, settings are
+// stored in gitscope/settings.json under the user's config directory.
+var FilePath string
+
+var mu sync.Mutex
+
+// Settings holds user preferences that persist across sessions.
+type Settings struct {
+	// CommitTemplates maps a template name to its message body.
+	CommitTemplates map[string]string `json:"commitTemplates,omitempty"`
+	// CommitHistory maps a repository path to its recent commit messages,
+	// newest first.
+	CommitHistory map[string][]string `json:"commitHistory,omitempty"`
+}
+
+func path() (string, error) {
+	if FilePath != "" {
+		return FilePath, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gitscope", "settings.json"), nil
+}
+
+// Load reads the settings file. A missing file yields empty settings.
+func Load() (Settings, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+func load() (Settings, error) {
+	var s Settings
+	p, err := path()
+	if err != nil {
+		return s, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return s, err
+	}
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+// Update loads the settings, applies fn and writes the result back.
+func Update(fn func(*Settings)) error {
+	mu.Lock()
+	defer mu.Unlock()
+	s, err := load()
+	if err != nil {
+		return err
+	}
+	fn(&s)
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0644)
+}
+
+// AddCommitMessage records msg as the most recent message for repo, moving
+// it to the front if it was already present and trimming the list to
+// MaxCommitHistory entries.
+func (s *Settings) AddCommitMessage(repo, msg string) {
+	if msg == "" {
+		return
+	}
+	if s.CommitHistory == nil {
+		s.CommitHistory = make(map[string][]string)
+	}
+	history := []string{msg}
+	for _, m := range s.CommitHistory[repo] {
+		if m != msg && len(history) < MaxCommitHistory {
+			history = append(history, m)
+		}
+	}
+	s.CommitHistory[repo] = history
+}
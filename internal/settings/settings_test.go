@@ -0,0 +1,44 @@
+package settings
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddCommitMessage(t *testing.T) {
+	var s Settings
+	s.AddCommitMessage("/repo", "first")
+	s.AddCommitMessage("/repo", "second")
+	s.AddCommitMessage("/repo", "first")
+	got := s.CommitHistory["/repo"]
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Errorf("unexpected history: %v", got)
+	}
+	for i := 0; i < MaxCommitHistory+5; i++ {
+		s.AddCommitMessage("/repo", fmt.Sprintf("msg %d", i))
+	}
+	if n := len(s.CommitHistory["/repo"]); n != MaxCommitHistory {
+		t.Errorf("expected %d entries, got %d", MaxCommitHistory, n)
+	}
+}
+
+func TestUpdateRoundTrip(t *testing.T) {
+	prev := FilePath
+	FilePath = filepath.Join(t.TempDir(), "nested", "settings.json")
+	defer func() { FilePath = prev }()
+
+	if s, err := Load(); err != nil || s.CommitTemplates != nil {
+		t.Fatalf("expected empty settings, got %+v, %v", s, err)
+	}
+	err := Update(func(s *Settings) {
+		s.CommitTemplates = map[string]string{"bugfix": "fix: "}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load()
+	if err != nil || s.CommitTemplates["bugfix"] != "fix: " {
+		t.Errorf("unexpected settings after reload: %+v, %v", s, err)
+	}
+}
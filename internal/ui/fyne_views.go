@@ -228,7 +228,7 @@ func InitButton(output *widget.Entry) *widget.Button {
 }
 
 func StatusButton(output *widget.Entry) fyne.CanvasObject {
-	options := []string{"Standard", "Short (-s)", "Branch (-b)"}
+	options := []string{"Standard", "Short (-s)", "Branch (-b)", "Submodules"}
 	statusSelect := widget.NewSelect(options, func(value string) {})
 	statusSelect.SetSelected("Standard")
 
@@ -1270,7 +1270,7 @@ func ShowButton(output *widget.Entry, w fyne.Window) fyne.CanvasObject {
 }
 
 func LsFilesButton(output *widget.Entry, w fyne.Window) fyne.CanvasObject {
-	options := []string{"Staged", "Tracked", "Untracked", "Modified", "Cached"}
+	options := []string{"Staged", "Tracked", "Untracked", "Modified", "Cached", "Sparse"}
 	lsSelect := widget.NewSelect(options, func(value string) {})
 	lsSelect.SetSelected("Tracked")
 
@@ -1279,12 +1279,14 @@ func LsFilesButton(output *widget.Entry, w fyne.Window) fyne.CanvasObject {
 			dialog.ShowInformation("Repository Not Selected", "Please select a repository first.", w)
 			return
 		}
+		// Values are the option names git.LsFiles accepts.
 		opt := map[string]string{
-			"Staged":    "--cached",
+			"Staged":    "Staged",
 			"Tracked":   "",
-			"Untracked": "--others --exclude-standard",
-			"Modified":  "--modified",
-			"Cached":    "--stage",
+			"Untracked": "Others (--others)",
+			"Modified":  "Modified (--modified)",
+			"Cached":    "Cached (--cached)",
+			"Sparse":    "Sparse (-t)",
 		}
 		val, ok := opt[lsSelect.Selected]
 		if !ok {
@@ -1438,7 +1440,15 @@ func RebaseButton(output *widget.Entry, w fyne.Window) fyne.CanvasObject {
 }
 
 func WorktreeButton(output *widget.Entry, w fyne.Window) fyne.CanvasObject {
-	options := []string{"List", "Add", "Remove", "Prune"}
+	options := []string{"List", "Open", "Add", "Remove", "Lock", "Unlock", "Move", "Repair", "Prune"}
+	placeholders := map[string]string{
+		"Add":    `"path" [branch]`,
+		"Remove": "worktree path",
+		"Lock":   "worktree path [reason]",
+		"Unlock": "worktree path",
+		"Move":   `"path" "new path"`,
+		"Repair": "moved worktree paths (optional)",
+	}
 	wtSelect := widget.NewSelect(options, func(value string) {})
 	wtSelect.SetSelected("List")
 
@@ -1447,15 +1457,36 @@ func WorktreeButton(output *widget.Entry, w fyne.Window) fyne.CanvasObject {
 			dialog.ShowInformation("Repository Not Selected", "Please select a repository first.", w)
 			return
 		}
-		action := strings.ToLower(wtSelect.Selected)
-		if action == "add" || action == "remove" {
-			input := widget.NewEntry()
-			placehold := "path new-branch" + string(rune(0xa7)) + "path"
-			if action == "remove" {
-				placehold = "worktree name/path"
+		action := wtSelect.Selected
+		if action == "List" || action == "Open" {
+			worktrees, err := git.ListWorktrees(state.RepoPath)
+			if err != nil {
+				output.SetText("error: " + err.Error())
+				return
 			}
+			if action == "List" {
+				output.SetText(formatWorktrees(worktrees))
+				return
+			}
+			var paths []string
+			for _, wt := range worktrees {
+				if !wt.Bare && !wt.Prunable {
+					paths = append(paths, wt.Path)
+				}
+			}
+			pathSelect := widget.NewSelect(paths, func(value string) {})
+			pathSelect.SetSelected(state.RepoPath)
+			dialog.ShowForm("Open Worktree", "Open", "Cancel", []*widget.FormItem{{Text: "Worktree", Widget: pathSelect}}, func(valid bool) {
+				if valid && pathSelect.Selected != "" {
+					output.SetText(core.SetRepo(pathSelect.Selected))
+				}
+			}, w)
+			return
+		}
+		if placehold, ok := placeholders[action]; ok {
+			input := widget.NewEntry()
 			input.SetPlaceHolder(placehold)
-			dialog.ShowForm("Worktree "+wtSelect.Selected, "Run", "Cancel", []*widget.FormItem{{Text: "Args", Widget: input}}, func(valid bool) {
+			dialog.ShowForm("Worktree "+action, "Run", "Cancel", []*widget.FormItem{{Text: "Args", Widget: input}}, func(valid bool) {
 				if valid {
 					out, err := git.Worktree(state.RepoPath, action, input.Text)
 					if err != nil {
@@ -1476,3 +1507,39 @@ func WorktreeButton(output *widget.Entry, w fyne.Window) fyne.CanvasObject {
 	})
 	return container.NewVBox(wtBtn, wtSelect)
 }
+
+// formatWorktrees renders worktrees one per line with their branch and state.
+func formatWorktrees(worktrees []git.WorktreeInfo) string {
+	var b strings.Builder
+	for _, wt := range worktrees {
+		head := wt.Head
+		if len(head) > 7 {
+			head = head[:7]
+		}
+		ref := "[" + wt.Branch + "]"
+		switch {
+		case wt.Bare:
+			ref = "(bare)"
+		case wt.Detached:
+			ref = "(detached HEAD)"
+		}
+		fmt.Fprintf(&b, "%s  %s %s", wt.Path, head, ref)
+		if wt.Main {
+			b.WriteString("  main")
+		}
+		if wt.Locked {
+			b.WriteString("  locked")
+			if wt.LockReason != "" {
+				b.WriteString(": " + wt.LockReason)
+			}
+		}
+		if wt.Prunable {
+			b.WriteString("  prunable")
+			if wt.PrunableReason != "" {
+				b.WriteString(": " + wt.PrunableReason)
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}